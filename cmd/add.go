@@ -3,55 +3,85 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/cacoco/codemetagenerator/internal/model"
 	"github.com/spf13/cobra"
 )
 
+// reports whether the resource is a person or organization valued property, e.g., "author"
+func isPartyResource(resource string) bool {
+	for _, property := range model.PartyProperties {
+		if resource == property.Name || resource == property.Flag() {
+			return true
+		}
+	}
+	return false
+}
+
+// reports whether the resource is a sub-command without args, e.g., "author" or "funding"
+func isNoArgsResource(resource string) bool {
+	switch resource {
+	case "funding", "language", "requirement":
+		return true
+	}
+	return isPartyResource(resource)
+}
+
+// reports whether the resource is a sub-command with at least one arg, e.g., "keyword"
+func isArgsResource(resource string) bool {
+	switch resource {
+	case "keyword", "runtime-platform", model.RuntimePlatform:
+		return true
+	}
+	return false
+}
+
 func checkArgs(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		// must specify a sub-command
-		return fmt.Errorf("this command must be run with a specific resource sub-command: author, contributor, or keyword")
+		return fmt.Errorf("this command must be run with a specific resource sub-command, e.g.: author, contributor, maintainer, funder or keyword")
 	}
-	if len(args) == 1 {
-		if args[0] == "keyword" {
-			// keywords MUST have at least one argument
-			return fmt.Errorf("this command must be run with at least one keyword argument")
-		}
-
-		// ensure the sub-command is either authors and contributors
-		if (args[0] != "author") && (args[0] != "contributor") {
-			return fmt.Errorf("unrecognized resource sub-command: %s", args[0])
-		}
+	if !isNoArgsResource(args[0]) && !isArgsResource(args[0]) {
+		return fmt.Errorf("unrecognized resource sub-command: %s", args[0])
 	}
-	if len(args) > 1 {
-		if args[0] != "keyword" {
-			// only keywords can have at least one argument
-			if (args[0] != "author") && (args[0] != "contributor") {
-				return fmt.Errorf("unrecognized resource sub-command: %s", args[0])
-			}
-			return fmt.Errorf("no args expected for the %s sub-command", args[0])
-		}
+	if len(args) == 1 && isArgsResource(args[0]) {
+		// keywords and runtime platforms MUST have at least one argument
+		return fmt.Errorf("this command must be run with at least one %s argument", args[0])
+	}
+	if len(args) > 1 && isNoArgsResource(args[0]) {
+		// only keywords and runtime platforms can have arguments
+		return fmt.Errorf("no args expected for the %s sub-command", args[0])
 	}
 
 	return nil
 }
 
 func addCmdRunE(cmd *cobra.Command, args []string) error {
-	return fmt.Errorf("this command must be run with a resource sub-command like author, contributor or keyword")
+	return fmt.Errorf("this command must be run with a resource sub-command like author, contributor, maintainer, funder or keyword")
+}
+
+func validAddArgs() []string {
+	args := []string{}
+	for _, property := range model.PartyProperties {
+		args = append(args, property.Name)
+	}
+	return append(args, "funding", "keyword", "language", "requirement", "runtime-platform")
 }
 
 // addCmd represents the add command
 var addCmd = &cobra.Command{
 	Use:       "add [command]",
-	ValidArgs: []string{"author", "contributor", "keyword"},
+	ValidArgs: validAddArgs(),
 	Args:      checkArgs,
-	Short:     "Adds resources [authors, contributors, keywords] to the in-progress codemeta.json file",
+	Short:     "Adds resources [authors, contributors, maintainers, funders, keywords, etc.] to the in-progress codemeta.json file",
 	Long: `
-Use this command to add authors, contributors, keywords, to the in-progress 
-codemeta.json file. You can choose to clear all of the data in a field by 
-running the "delete" command. When you are done adding resources, run 
+Use this command to add authors, contributors, maintainers, keywords, funding, 
+software requirements, programming languages, runtime platforms, and other people 
+and organizations, e.g., funders, copyright holders, publishers or editors, to the 
+in-progress codemeta.json file. You can choose to clear all of the data in a field 
+by running the "delete" command. When you are done adding resources, run 
 "generate" to generate the resultant 'codemeta.json' file. 
 
-Note that this command must be run with a resource sub-command like author, contributor or keyword.`,
+Note that this command must be run with a resource sub-command like author, contributor, maintainer, funder or keyword.`,
 	RunE: addCmdRunE,
 }
 
@@ -74,3 +74,17 @@ func Test_ExecuteAddCmd4(t *testing.T) {
 		t.Errorf("expected error for add command")
 	}
 }
+
+func Test_ExecuteAddCmd5(t *testing.T) {
+	add := &cobra.Command{Use: "add", Args: checkArgs, RunE: addCmdRunE}
+	b := bytes.NewBufferString("")
+	add.SetOut(b)
+	add.SetErr(b)
+
+	add.SetArgs([]string{"runtime-platform"}) // should have at least one more arg
+
+	err := add.Execute()
+	if err == nil {
+		t.Errorf("expected error for add command")
+	}
+}
@@ -42,7 +42,7 @@ func TestAddAuthor(t *testing.T) {
 	reader := utils.TestReader{In: utils.TestStdin{Data: stack}}
 	writer := utils.TestWriter{}
 
-	author, err := author(&reader, &writer, temp)
+	author, err := author(&reader, &writer, temp, &utils.Input{})
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -87,7 +87,7 @@ func Test_ExecuteAuthorCmd(t *testing.T) {
 	writer := utils.TestWriter{}
 
 	authorCmd := &cobra.Command{Use: "author", Args: cobra.NoArgs, RunE: func(cmd *cobra.Command, args []string) error {
-		_, err := author(&reader, &writer, temp)
+		_, err := author(&reader, &writer, temp, &utils.Input{})
 		return err
 	},
 	}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/diff"
+	"github.com/cacoco/codemetagenerator/internal/patch"
+	"github.com/cacoco/codemetagenerator/internal/sources"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// compares the checked-in codemeta.json file with the same file updated from the project
+// manifests next to it. Never writes anything.
+func check(writer utils.Writer, codemetaFile string, sourceNames []string) ([]diff.Change, error) {
+	checkedIn, err := loadDocument(writer, codemetaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	derived, used, err := sources.Derive(filepath.Dir(codemetaFile), sourceNames)
+	if err != nil {
+		return nil, err
+	}
+	if len(used) == 0 {
+		return nil, writer.Errorf("no sources found next to %s, supported sources are: %s", codemetaFile, strings.Join(sources.FileNames(), ", "))
+	}
+
+	expected := patch.MergePatch(patch.DeepCopy(checkedIn), sources.Patch(checkedIn, derived))
+	changes := diff.Diff(checkedIn, expected)
+	if len(changes) > 0 {
+		writer.Println(diff.Format(changes))
+		return changes, writer.Errorf("%s is out of date with %s", codemetaFile, strings.Join(used, ", "))
+	}
+	writer.Println(fmt.Sprintf("✅ The codemeta file '%s' is up to date with %s.", filepath.Base(codemetaFile), strings.Join(used, ", ")))
+	return changes, nil
+}
+
+var checkSources []string
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check [path/to/codemeta.json] [--source <manifest>]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Check that a codemeta.json file is up to date with the project manifests",
+	Long: `
+Check that a checked-in codemeta.json file has not drifted from the project manifests next
+to it, e.g., when the version in package.json was bumped but codemeta.json was not.
+
+The version, description, license, url, codeRepository and issueTracker properties are
+derived from the supported manifests (package.json, Cargo.toml, pyproject.toml and
+go.mod) and compared with the codemeta.json file. From go.mod the codeRepository is
+derived from the module path, and Go and the version of the go directive, e.g., go1.21,
+are expected among the programmingLanguage and runtimePlatform entries. When they differ,
+the differences are printed and the command exits with a non-zero status. Like "gofmt -l" this never writes anything,
+which makes it suitable for CI.
+
+If no file is given, the 'codemeta.json' file in the working directory is checked. By
+default all supported manifests which exist are used, pass [--source <manifest>] one or
+more times to require specific manifests.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		codemetaFile := utils.ProjectFileName
+		if len(args) == 1 {
+			codemetaFile = args[0]
+		} else if isProjectLocal() {
+			codemetaFile = ProjectFile
+		}
+		_, err := check(&utils.StdoutWriter{}, codemetaFile, checkSources)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringArrayVar(&checkSources, "source", []string{}, "manifest to derive properties from: package.json, Cargo.toml, pyproject.toml or go.mod")
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/diff"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestCheck(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	codemetaFile := filepath.Join(temp, utils.ProjectFileName)
+	err := utils.Marshal(codemetaFile, *model.NewCodemeta(&map[string]any{model.Name: "project", model.Version: "1.0.0"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	before, _ := utils.LoadFile(codemetaFile)
+	writer := utils.TestWriter{}
+
+	utils.WriteFile(filepath.Join(temp, "package.json"), []byte(`{"name": "project", "version": "1.0.0"}`))
+	changes, err := check(&writer, codemetaFile, []string{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(changes).Should(gomega.BeEmpty())
+
+	// the version was bumped
+	utils.WriteFile(filepath.Join(temp, "package.json"), []byte(`{"name": "project", "version": "1.1.0"}`))
+	changes, err = check(&writer, codemetaFile, []string{})
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Ω(changes).Should(gomega.Equal([]diff.Change{{Kind: diff.Changed, Path: model.Version, Old: "1.0.0", New: "1.1.0"}}))
+
+	// nothing is written
+	after, _ := utils.LoadFile(codemetaFile)
+	g.Ω(after).Should(gomega.Equal(before))
+}
+
+func TestCheckGoMod(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	codemetaFile := filepath.Join(temp, utils.ProjectFileName)
+	utils.WriteJSON(codemetaFile, `{
+		"codeRepository": "https://github.com/org/project",
+		"programmingLanguage": {"@type": "ComputerLanguage", "name": "go", "url": "https://go.dev/"},
+		"runtimePlatform": "go version go1.21.6"
+	}`)
+	writer := utils.TestWriter{}
+
+	utils.WriteFile(filepath.Join(temp, "go.mod"), []byte("module github.com/org/project\n\ngo 1.21.6\n"))
+	changes, err := check(&writer, codemetaFile, []string{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(changes).Should(gomega.BeEmpty())
+
+	// the go directive was bumped
+	utils.WriteFile(filepath.Join(temp, "go.mod"), []byte("module github.com/org/project\n\ngo 1.22\n"))
+	changes, err = check(&writer, codemetaFile, []string{"go.mod"})
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Ω(changes).ShouldNot(gomega.BeEmpty())
+	g.Ω(changes[0].Path).Should(gomega.HavePrefix(model.RuntimePlatform))
+}
+
+func TestCheckNoSources(t *testing.T) {
+	temp := t.TempDir()
+	codemetaFile := filepath.Join(temp, utils.ProjectFileName)
+	utils.WriteJSON(codemetaFile, `{}`)
+
+	writer := utils.TestWriter{}
+	_, err := check(&writer, codemetaFile, []string{})
+	if err == nil {
+		t.Errorf("Expected error")
+	}
+}
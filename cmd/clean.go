@@ -3,36 +3,93 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
-func clean(basedir string, writer utils.Writer) error {
-	codemetageneratorHomeDir := utils.GetHomeDir(basedir)
-	if _, err := os.Stat(codemetageneratorHomeDir); err == nil {
-		err := os.RemoveAll(codemetageneratorHomeDir)
+var cleanAll bool
+
+// removes the cache and state directories, and with all the configuration directory. The
+// configuration files in a cache or state directory, e.g., the $HOME/.codemetagenerator
+// directory, are kept unless all is set.
+func clean(basedir string, writer utils.Writer, all bool) error {
+	dirs := utils.CurrentDirs(basedir)
+	if all {
+		for _, dir := range dirs.All() {
+			err := removeDir(writer, dir)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, dir := range lo.Uniq([]string{dirs.Cache, dirs.State}) {
+		if dir != dirs.Config {
+			err := removeDir(writer, dir)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
 		if err != nil {
 			handleErr(writer, err)
-			return fmt.Errorf("unable to remove codemetagenerator home directory")
+			return fmt.Errorf("unable to read codemetagenerator directory %s", dir)
+		}
+		for _, entry := range entries {
+			if lo.Contains(configFileNames(), entry.Name()) {
+				continue
+			}
+			err := os.RemoveAll(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				handleErr(writer, err)
+				return fmt.Errorf("unable to clean codemetagenerator directory %s", dir)
+			}
 		}
-		writer.Println("✅ Successfully cleaned the $HOME/.codemetagenerator directory.")
+		writer.Println(fmt.Sprintf("✅ Successfully cleaned the %s directory, the configuration files were kept.", dir))
 	}
 	return nil
 }
 
+func removeDir(writer utils.Writer, dir string) error {
+	if _, err := os.Stat(dir); err != nil {
+		return nil
+	}
+	err := os.RemoveAll(dir)
+	if err != nil {
+		handleErr(writer, err)
+		return fmt.Errorf("unable to remove codemetagenerator directory %s", dir)
+	}
+	writer.Println(fmt.Sprintf("✅ Successfully cleaned the %s directory.", dir))
+	return nil
+}
+
 // cleanCmd represents the clean command
 var cleanCmd = &cobra.Command{
-	Use:   "clean",
+	Use:   "clean [--all]",
 	Args:  cobra.NoArgs,
-	Short: "Clean the $HOME/.codemetagenerator directory",
+	Short: "Clean the codemetagenerator directories",
 	Long: `
-Removes the $HOME/.codemetagenerator directory used to store the in-progress codemeta.json file.`,
+Removes the codemetagenerator cache and state directories, by default the
+$HOME/.codemetagenerator directory, used to store the downloaded SPDX licenses and the
+in-progress codemeta.json files.
+
+The configuration, config.yaml, the profile and the address book, is kept unless --all
+is given, also when it is kept in the same directory.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return clean(utils.UserHomeDir, &utils.StdoutWriter{})
+		return clean(utils.UserHomeDir, &utils.StdoutWriter{}, cleanAll)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(cleanCmd)
+
+	cleanCmd.Flags().BoolVar(&cleanAll, "all", false, "also remove the configuration directory with the configuration, the profile and the address book")
 }
@@ -5,7 +5,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/cacoco/codemetagenerator/internal/config"
+	"github.com/cacoco/codemetagenerator/internal/people"
 	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +20,7 @@ func Test_ExecuteCleanCmd1(t *testing.T) {
 	writer := &utils.TestWriter{}
 
 	new := &cobra.Command{Use: "new", RunE: func(cmd *cobra.Command, args []string) error {
-		return clean(temp, writer)
+		return clean(temp, writer, true)
 	},
 	}
 	buf := bytes.NewBufferString("")
@@ -43,7 +46,7 @@ func Test_ExecuteCleanCmd2(t *testing.T) {
 	writer := &utils.TestWriter{}
 
 	new := &cobra.Command{Use: "new", RunE: func(cmd *cobra.Command, args []string) error {
-		return clean(temp, writer)
+		return clean(temp, writer, false)
 	},
 	}
 	buf := bytes.NewBufferString("")
@@ -61,3 +64,65 @@ func Test_ExecuteCleanCmd2(t *testing.T) {
 		t.Errorf("Directory should not exist")
 	}
 }
+
+func Test_ExecuteCleanCmd3(t *testing.T) {
+	// removes each of the XDG directories
+	temp := t.TempDir()
+	defer (&utils.Dirs{}).Use()
+	dirs := &utils.Dirs{Config: temp + "/config/codemetagenerator", Cache: temp + "/cache/codemetagenerator", State: temp + "/state/codemetagenerator"}
+	dirs.Use()
+	utils.MkHomeDir(temp)
+
+	// the configuration directory is kept
+	err := clean(temp, &utils.TestWriter{}, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, dir := range []string{dirs.Cache, dirs.State} {
+		if _, err := os.Stat(dir); err == nil {
+			t.Errorf("Directory %s should have been removed", dir)
+		}
+	}
+	if _, err := os.Stat(dirs.Config); err != nil {
+		t.Errorf("Directory %s should not have been removed", dirs.Config)
+	}
+
+	err = clean(temp, &utils.TestWriter{}, true)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, dir := range dirs.All() {
+		if _, err := os.Stat(dir); err == nil {
+			t.Errorf("Directory %s should have been removed", dir)
+		}
+	}
+	// the parent directories are kept
+	if _, err := os.Stat(temp + "/config"); err != nil {
+		t.Errorf("Directory should not have been removed")
+	}
+}
+
+func TestCleanKeepsConfiguration(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// the configuration, cache and state share the .codemetagenerator directory
+	temp := t.TempDir()
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	utils.MkDraftsDir(temp)
+	for _, path := range []string{config.GetFilePath(temp), utils.GetProfileFilePath(temp), people.GetFilePath(temp), utils.GetInProgressFilePath(temp), utils.GetLicensesFilePath(temp)} {
+		err := utils.WriteFile(path, []byte("{}"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	err := clean(temp, &utils.TestWriter{}, false)
+	g.Expect(err).To(gomega.BeNil())
+	entries, err := os.ReadDir(utils.GetHomeDir(temp))
+	g.Expect(err).To(gomega.BeNil())
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	g.Ω(names).Should(gomega.ConsistOf(configFileNames()))
+}
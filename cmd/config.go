@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cacoco/codemetagenerator/internal/config"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var configRepo bool
+
+func loadedConfig(writer utils.Writer, c *config.Config) (*config.Config, error) {
+	// without a directory there is no configuration to load
+	if dirsErr != nil {
+		return nil, dirsErr
+	}
+	if configErr != nil {
+		handleErr(writer, configErr)
+		return nil, writer.Errorf("invalid configuration: %s", configErr.Error())
+	}
+	if c == nil {
+		return nil, writer.Errorf("the configuration has not been loaded")
+	}
+	return c, nil
+}
+
+func configGet(writer utils.Writer, c *config.Config, name string) (*config.Value, error) {
+	c, err := loadedConfig(writer, c)
+	if err != nil {
+		return nil, err
+	}
+	value, err := c.Lookup(name)
+	if err != nil {
+		return nil, writer.Errorf(err.Error())
+	}
+	writer.Println(value.Value)
+	return &value, nil
+}
+
+func configList(writer utils.Writer, c *config.Config) error {
+	c, err := loadedConfig(writer, c)
+	if err != nil {
+		return err
+	}
+	for _, key := range config.Keys {
+		value, _ := c.Lookup(key.Name)
+		writer.Println(fmt.Sprintf("%s=%s\t(%s)", key.Name, value.Value, value.Source))
+	}
+	return nil
+}
+
+// sets the value in the configuration file at path, an empty value removes it
+func configSet(writer utils.Writer, path string, name string, value string) error {
+	err := config.SetValue(path, name, value)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to set %s: %s", name, err.Error())
+	}
+	if value == "" {
+		writer.Println(fmt.Sprintf("⭐ Successfully removed %s from '%s'.", name, path))
+	} else {
+		writer.Println(fmt.Sprintf("⭐ Successfully set %s in '%s'.", name, path))
+	}
+	return nil
+}
+
+// returns the help text of the configuration keys
+func configKeysHelp() string {
+	help := ""
+	for _, key := range config.Keys {
+		help += fmt.Sprintf("  %-14s%s (%s)\n", key.Name, key.Description, key.Env())
+	}
+	return help
+}
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Args:  cobra.NoArgs,
+	Short: "Manage the configuration [get, set, list]",
+	Long: `
+Manage the configuration. The configuration is layered, in increasing precedence:
+
+  1. the user file config.yaml in the configuration directory, see "--help" of the
+     root command
+  2. the repository file .codemetagenerator.yaml in the working directory or the root
+     of its git repository
+  3. the CODEMETAGENERATOR_* environment variables
+  4. the -c name=value flags
+
+The configuration keys and their environment variables are:
+
+` + configKeysHelp(),
+	// the configuration commands neither need the SPDX licenses nor a valid configuration,
+	// but they need its directory
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		initConfig()
+		return dirsErr
+	},
+}
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:   "get <name>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Print the effective value of a configuration key",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := configGet(&utils.StdoutWriter{}, Config, args[0])
+		return err
+	},
+}
+
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.NoArgs,
+	Short: "List the effective configuration values and where they were set",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return configList(&utils.StdoutWriter{}, Config)
+	},
+}
+
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:   "set <name> <value>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Set a configuration value in the user or repository configuration file",
+	Long: `
+Set a configuration value in the user configuration file, or with --repo in the
+repository configuration file. An empty value removes the key from the file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := config.GetFilePath(utils.UserHomeDir)
+		if configRepo {
+			workingDir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			path, _ = config.FindRepoFile(workingDir)
+		}
+		return configSet(&utils.StdoutWriter{}, path, args[0], args[1])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configSetCmd)
+
+	configSetCmd.Flags().BoolVar(&configRepo, "repo", false, "set the value in the repository configuration file .codemetagenerator.yaml")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cacoco/codemetagenerator/internal/config"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestConfig(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	home := t.TempDir()
+	writer := utils.TestWriter{}
+
+	err := configSet(&writer, config.GetFilePath(home), config.License, "MIT")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = configSet(&writer, config.GetFilePath(home), config.HTTPTimeout, "never")
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	c, err := config.Load(home, t.TempDir(), func(string) (string, bool) { return "", false }, []string{"http-timeout=10s"})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	value, err := configGet(&writer, c, config.License)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(value.Value).Should(gomega.Equal("MIT"))
+	_, err = configGet(&writer, c, "unknown")
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(configList(&writer, c)).To(gomega.BeNil())
+}
+
+func TestConfigWithoutDirectory(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dirsErr = fmt.Errorf("unable to resolve the codemetagenerator directory")
+	defer func() { dirsErr = nil }()
+	writer := utils.TestWriter{}
+
+	_, err := configGet(&writer, nil, config.License)
+	g.Expect(err).To(gomega.Equal(dirsErr))
+	g.Expect(configList(&writer, nil)).To(gomega.Equal(dirsErr))
+}
+
+func TestApplyConfig(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// restore the package level settings
+	defer func(url string, timeout time.Duration, context string) {
+		utils.SPDXLicensesURL = url
+		utils.HttpTimeout = timeout
+		model.ConfiguredContext = context
+	}(utils.SPDXLicensesURL, utils.HttpTimeout, model.ConfiguredContext)
+
+	c := config.New()
+	err := c.LoadFlags([]string{
+		"spdx-url=https://example.org/licenses.json",
+		"http-timeout=1m",
+		"context=https://doi.org/10.5063/schema/codemeta-2.0",
+	})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	applyConfig(c)
+	g.Ω(utils.SPDXLicensesURL).Should(gomega.Equal("https://example.org/licenses.json"))
+	g.Ω(utils.HttpTimeout).Should(gomega.Equal(time.Minute))
+	g.Ω((*model.NewCodemeta(&map[string]any{}))[model.Context]).Should(gomega.Equal("https://doi.org/10.5063/schema/codemeta-2.0"))
+}
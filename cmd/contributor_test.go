@@ -3,6 +3,8 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/cacoco/codemetagenerator/internal/model"
@@ -42,7 +44,7 @@ func TestAddContributor(t *testing.T) {
 
 	writer := utils.TestWriter{}
 
-	contributor, err := contributor(&reader, &writer, temp)
+	contributor, err := contributor(&reader, &writer, temp, &utils.Input{})
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -85,7 +87,7 @@ func Test_ExecuteContributorCmd(t *testing.T) {
 	writer := utils.TestWriter{}
 
 	contributorCmd := &cobra.Command{Use: "contributor", Args: cobra.NoArgs, RunE: func(cmd *cobra.Command, args []string) error {
-		_, err := contributor(&reader, &writer, temp)
+		_, err := contributor(&reader, &writer, temp, &utils.Input{})
 		return err
 	},
 	}
@@ -122,3 +124,79 @@ func Test_ExecuteContributorCmd(t *testing.T) {
 
 	g.Ω(m).Should(gomega.Equal(expected))
 }
+
+// creates a git repository with one commit for each of the given authors
+func gitRepoWithAuthors(t *testing.T, authors ...string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	run := func(env []string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run(nil, "init", "-q")
+	for _, author := range authors {
+		name, email, _ := strings.Cut(author, " <")
+		email = strings.TrimSuffix(email, ">")
+		run([]string{
+			"GIT_AUTHOR_NAME=" + name, "GIT_AUTHOR_EMAIL=" + email,
+			"GIT_COMMITTER_NAME=" + name, "GIT_COMMITTER_EMAIL=" + email,
+		}, "commit", "--allow-empty", "-q", "-m", "commit")
+	}
+	return dir
+}
+
+func TestAddContributorsFromGit(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	repo := gitRepoWithAuthors(t, "Jane Doe <jane@example.com>", "John Smith <john@example.com>", "Jane Doe <jane@example.com>", "Ann Lee <ann@example.com>")
+
+	testMap := map[string]any{
+		model.Context: model.DefaultContext,
+		model.Type:    model.SoftwareSourceCodeType,
+		model.Contributor: []any{
+			map[string]any{model.Type: model.PersonType, model.GivenName: "John", model.Email: "JOHN@example.com"},
+		},
+	}
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, testMap)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// ranked by commits: Done, Jane Doe, Ann Lee
+	var stack utils.Stack[string]
+	stack.Push("\n")  // done
+	stack.Push("j\n") // Jane Doe
+	reader := utils.TestReader{In: utils.TestStdin{Data: stack}}
+	writer := utils.TestWriter{}
+
+	contributors, err := partiesFromGit(&reader, &writer, temp, partyProperty(model.Contributor), repo, &utils.Input{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(contributors).Should(gomega.HaveLen(1))
+	g.Ω(contributors[0].(map[string]any)[model.Email]).Should(gomega.Equal("jane@example.com"))
+
+	// the remaining author is added without prompting, existing emails are not duplicated
+	contributors, err = partiesFromGit(&reader, &writer, temp, partyProperty(model.Contributor), repo, &utils.Input{NoInput: true})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(contributors).Should(gomega.HaveLen(1))
+	g.Ω(contributors[0].(map[string]any)[model.Email]).Should(gomega.Equal("ann@example.com"))
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Contributor]).Should(gomega.HaveLen(3))
+}
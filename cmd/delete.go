@@ -7,7 +7,7 @@ import (
 )
 
 func delete(writer utils.Writer, basedir string, propertyPath string) error {
-	bytes, err := utils.LoadFile(utils.GetInProgressFilePath(basedir))
+	bytes, err := utils.LoadFile(getInProgressFilePath(basedir))
 	if err != nil {
 		handleErr(writer, err)
 		return writer.Errorf("unable to load the in-progress codemeta.json file")
@@ -17,7 +17,7 @@ func delete(writer utils.Writer, basedir string, propertyPath string) error {
 	if err != nil {
 		return err
 	}
-	return utils.MarshalBytes(utils.GetInProgressFilePath(basedir), []byte(*result))
+	return saveInProgressFile(getInProgressFilePath(basedir), []byte(*result))
 }
 
 func deleteValue(writer utils.Writer, jsonBytes []byte, path string) (*string, error) {
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/cacoco/codemetagenerator/internal/diff"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffFormatText = "text"
+	diffFormatJSON = "json"
+
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// resolves the files to compare: with no arguments the 'codemeta.json' file in the
+// working directory is compared with the in-progress file, with one argument the
+// given file is compared with the in-progress file
+func diffArgs(basedir string, args []string) (string, string) {
+	switch len(args) {
+	case 0:
+		return utils.ProjectFileName, getInProgressFilePath(basedir)
+	case 1:
+		return args[0], getInProgressFilePath(basedir)
+	default:
+		return args[0], args[1]
+	}
+}
+
+func formatChanges(writer utils.Writer, changes []diff.Change, format string, color string) error {
+	switch format {
+	case diffFormatJSON:
+		writer.Println(diff.FormatJSON(changes))
+	case diffFormatText:
+		if len(changes) == 0 {
+			writer.Println("No changes.")
+			return nil
+		}
+		colored := color == colorAlways
+		if color == colorAuto {
+			colored = isTerminal(os.Stdout)
+		}
+		if colored {
+			writer.Println(diff.FormatColor(changes))
+		} else {
+			writer.Println(diff.Format(changes))
+		}
+	default:
+		return writer.Errorf("invalid format: %s, must be one of: %s, %s", format, diffFormatText, diffFormatJSON)
+	}
+	return nil
+}
+
+func diffFiles(writer utils.Writer, fromFile string, toFile string, format string, color string) ([]diff.Change, error) {
+	if color != colorAuto && color != colorAlways && color != colorNever {
+		return nil, writer.Errorf("invalid color: %s, must be one of: %s, %s, %s", color, colorAuto, colorAlways, colorNever)
+	}
+	from, err := loadDocument(writer, fromFile)
+	if err != nil {
+		return nil, err
+	}
+	to, err := loadDocument(writer, toFile)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := diff.Diff(from, to)
+	err = formatChanges(writer, changes, format, color)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+var diffFormat string
+var diffColor string
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff [a.json] [b.json]",
+	Args:  cobra.MaximumNArgs(2),
+	Short: "Show the structural differences between two codemeta.json files",
+	Long: `
+Show the structural differences between two codemeta.json files as a list of added (+),
+removed (-) and changed (~) values. Each value is identified by its path in the same
+syntax used by the "set" and "delete" commands. Persons and organizations in a list
+are matched by their "@id" or email rather than by position.
+
+With no arguments, the 'codemeta.json' file in the working directory is compared with
+the in-progress file, i.e., the changes "generate -o codemeta.json" would make. With a
+single argument, the given file is compared with the in-progress file.
+
+Output is colored when printed to a terminal [--color auto|always|never] and can be
+printed as JSON instead [--format json].`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to := diffArgs(utils.UserHomeDir, args)
+		_, err := diffFiles(&utils.StdoutWriter{}, from, to, diffFormat, diffColor)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVar(&diffFormat, "format", diffFormatText, "output format: text or json")
+	diffCmd.Flags().StringVar(&diffColor, "color", colorAuto, "color the text output: auto, always or never")
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/diff"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestDiffArgs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	from, to := diffArgs(temp, []string{})
+	g.Ω(from).Should(gomega.Equal(utils.ProjectFileName))
+	g.Ω(to).Should(gomega.Equal(utils.GetInProgressFilePath(temp)))
+
+	from, to = diffArgs(temp, []string{"a.json"})
+	g.Ω(from).Should(gomega.Equal("a.json"))
+	g.Ω(to).Should(gomega.Equal(utils.GetInProgressFilePath(temp)))
+
+	from, to = diffArgs(temp, []string{"a.json", "b.json"})
+	g.Ω(from).Should(gomega.Equal("a.json"))
+	g.Ω(to).Should(gomega.Equal("b.json"))
+}
+
+func TestDiffFiles(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	err := utils.Marshal(utils.GetInProgressFilePath(temp), *model.NewCodemeta(&map[string]any{model.Name: "name", model.Version: "1.1.0"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	target := temp + "/codemeta.json"
+	err = utils.Marshal(target, *model.NewCodemeta(&map[string]any{model.Name: "name", model.Version: "1.0.0"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	writer := utils.TestWriter{}
+	from, to := diffArgs(temp, []string{target})
+	changes, err := diffFiles(&writer, from, to, diffFormatJSON, colorNever)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(changes).Should(gomega.Equal([]diff.Change{{Kind: diff.Changed, Path: model.Version, Old: "1.0.0", New: "1.1.0"}}))
+
+	_, err = diffFiles(&writer, from, to, "yaml", colorNever)
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	_, err = diffFiles(&writer, from, to, diffFormatText, "sometimes")
+	g.Expect(err).ToNot(gomega.BeNil())
+}
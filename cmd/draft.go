@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func draftList(writer utils.Writer, basedir string) ([]string, error) {
+	drafts, err := utils.ListDrafts(basedir)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to list drafts")
+	}
+	current := utils.GetCurrentDraft(basedir)
+	for _, draft := range drafts {
+		if draft == current {
+			writer.Println("* " + draft)
+		} else {
+			writer.Println("  " + draft)
+		}
+	}
+	return drafts, nil
+}
+
+func draftSwitch(writer utils.Writer, basedir string, name string) error {
+	err := utils.ValidDraftName(name)
+	if err != nil {
+		return err
+	}
+	if !utils.DraftExists(basedir, name) {
+		return writer.Errorf("draft '%s' does not exist, run \"codemetagenerator draft create %s\" to create it", name, name)
+	}
+	err = utils.SetCurrentDraft(basedir, name)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to switch to draft '%s'", name)
+	}
+	writer.Println(fmt.Sprintf("✅ Switched to draft '%s'.", name))
+	return nil
+}
+
+func draftCreate(writer utils.Writer, basedir string, name string) error {
+	err := utils.ValidDraftName(name)
+	if err != nil {
+		return err
+	}
+	if utils.DraftExists(basedir, name) {
+		return writer.Errorf("draft '%s' already exists", name)
+	}
+	err = utils.MkDraftsDir(basedir)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to create draft '%s'", name)
+	}
+
+	codemeta := *model.NewCodemeta(&map[string]any{})
+	err = utils.Marshal(utils.GetDraftFilePath(basedir, name), codemeta)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to create draft '%s'", name)
+	}
+	err = utils.SetCurrentDraft(basedir, name)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to switch to draft '%s'", name)
+	}
+	writer.Println(fmt.Sprintf("⭐ Successfully created and switched to draft '%s'.", name))
+	return nil
+}
+
+func draftDelete(writer utils.Writer, basedir string, name string) error {
+	err := utils.ValidDraftName(name)
+	if err != nil {
+		return err
+	}
+	if !utils.DraftExists(basedir, name) {
+		return writer.Errorf("draft '%s' does not exist", name)
+	}
+	err = utils.DeleteFile(utils.GetDraftFilePath(basedir, name))
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to delete draft '%s'", name)
+	}
+	// deleting the current draft falls back to the default draft
+	if utils.GetCurrentDraft(basedir) == name {
+		err = utils.SetCurrentDraft(basedir, utils.DefaultDraftName)
+		if err != nil {
+			handleErr(writer, err)
+			return writer.Errorf("unable to switch to the default draft")
+		}
+	}
+	writer.Println(fmt.Sprintf("✅ Successfully deleted draft '%s'.", name))
+	return nil
+}
+
+// draftCmd represents the draft command
+var draftCmd = &cobra.Command{
+	Use:   "draft [command]",
+	Args:  cobra.NoArgs,
+	Short: "Manage named drafts [list, switch, create, delete] of in-progress codemeta.json files",
+	Long: `
+Use this command to manage named drafts. Each draft is a separate in-progress
+codemeta.json file, allowing you to work on more than one project at a time.
+
+Every command operates on the current draft, which is selected with "draft switch".
+A different draft can be selected for a single command with the global
+[--draft <name>] flag. The "default" draft is the original in-progress file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := draftList(&utils.StdoutWriter{}, utils.UserHomeDir)
+		return err
+	},
+}
+
+var draftListCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.NoArgs,
+	Short: "List the drafts, marking the current draft with '*'",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := draftList(&utils.StdoutWriter{}, utils.UserHomeDir)
+		return err
+	},
+}
+
+var draftSwitchCmd = &cobra.Command{
+	Use:   "switch <name>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Switch the current draft",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return draftSwitch(&utils.StdoutWriter{}, utils.UserHomeDir, args[0])
+	},
+}
+
+var draftCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Create a new empty draft and switch to it",
+	Long: `
+Create a new draft with an empty in-progress codemeta.json file and make it the
+current draft. Run "new --input <path/to/codemeta.json>" to load an existing file
+into the draft instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return draftCreate(&utils.StdoutWriter{}, utils.UserHomeDir, args[0])
+	},
+}
+
+var draftDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Delete a draft. Deleting the current draft switches back to the default draft",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return draftDelete(&utils.StdoutWriter{}, utils.UserHomeDir, args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(draftCmd)
+
+	draftCmd.AddCommand(draftListCmd)
+	draftCmd.AddCommand(draftSwitchCmd)
+	draftCmd.AddCommand(draftCreateCmd)
+	draftCmd.AddCommand(draftDeleteCmd)
+}
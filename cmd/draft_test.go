@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+func TestDraftCreateSwitchDelete(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	writer := utils.TestWriter{}
+
+	err := utils.Marshal(utils.GetInProgressFilePath(temp), *model.NewCodemeta(&map[string]any{model.Name: "default"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	err = draftCreate(&writer, temp, "project")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(utils.GetCurrentDraft(temp)).Should(gomega.Equal("project"))
+	g.Ω(getInProgressFilePath(temp)).Should(gomega.Equal(utils.GetDraftFilePath(temp, "project")))
+
+	// creating an existing draft fails
+	err = draftCreate(&writer, temp, "project")
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	drafts, err := draftList(&writer, temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(drafts).Should(gomega.Equal([]string{utils.DefaultDraftName, "project"}))
+
+	err = draftSwitch(&writer, temp, utils.DefaultDraftName)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(getInProgressFilePath(temp)).Should(gomega.Equal(utils.GetInProgressFilePath(temp)))
+
+	// switching to an unknown draft fails
+	err = draftSwitch(&writer, temp, "unknown")
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	err = draftSwitch(&writer, temp, "project")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = draftDelete(&writer, temp, "project")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// deleting the current draft falls back to the default draft
+	g.Ω(utils.GetCurrentDraft(temp)).Should(gomega.Equal(utils.DefaultDraftName))
+	g.Expect(utils.DraftExists(temp, "project")).To(gomega.BeFalse())
+}
+
+func Test_ExecuteKeywordCmdWithDraftFlag(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	writer := utils.TestWriter{}
+
+	err := draftCreate(&writer, temp, "other")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = draftCreate(&writer, temp, "project")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	Draft = "other"
+	defer func() { Draft = "" }()
+
+	keywordCmd := &cobra.Command{Use: "keyword", RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := keyword(&writer, temp, args)
+		return err
+	},
+	}
+	buf := bytes.NewBufferString("")
+	keywordCmd.SetOut(buf)
+	keywordCmd.SetErr(buf)
+	keywordCmd.SetArgs([]string{"keyword1"})
+
+	err = keywordCmd.Execute()
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// only the draft selected by the flag is updated
+	other, err := utils.Unmarshal(utils.GetDraftFilePath(temp, "other"))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*other)[model.Keywords]).Should(gomega.Equal([]any{"keyword1"}))
+
+	project, err := utils.Unmarshal(utils.GetDraftFilePath(temp, "project"))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*project)[model.Keywords]).Should(gomega.BeNil())
+}
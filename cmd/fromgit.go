@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/gitlog"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var fromGit bool
+var gitSort string
+var gitSince string
+var gitUntil string
+
+func addFromGitFlags(cmd *cobra.Command, label string) {
+	cmd.Flags().BoolVar(&fromGit, "from-git", false, fmt.Sprintf("select %s from the commit authors of the git repository in the working directory", label))
+	cmd.Flags().StringVar(&gitSort, "sort", gitlog.SortCommits, "rank the commit authors by: commits or recent")
+	cmd.Flags().StringVar(&gitSince, "since", "", "only read commits more recent than the date, e.g., 2024-01-01")
+	cmd.Flags().StringVar(&gitUntil, "until", "", "only read commits older than the date, e.g., 2024-12-31")
+}
+
+// returns the lower-cased emails of the persons in the given value, a single person or a list
+func existingEmails(value any) map[string]bool {
+	emails := make(map[string]bool)
+	for _, item := range appendAll(value, nil) {
+		if m, ok := item.(map[string]any); ok {
+			if email, ok := m[model.Email].(string); ok && email != "" {
+				emails[strings.ToLower(email)] = true
+			}
+		}
+	}
+	return emails
+}
+
+func labels(identities []gitlog.Identity) []string {
+	items := make([]string, len(identities))
+	for i, identity := range identities {
+		items[i] = identity.String()
+	}
+	return items
+}
+
+// lets the user select any number of the items, one at a time, until "Done" is selected.
+// Returns the indexes of the selected items in the order they were selected.
+func selectMany(reader utils.Reader, writer utils.Writer, label string, items []string) ([]int, error) {
+	done := "✔ Done"
+	remaining := make([]int, len(items))
+	for i := range items {
+		remaining[i] = i
+	}
+	selected := []int{}
+	for len(remaining) > 0 {
+		options := []string{done}
+		for _, i := range remaining {
+			options = append(options, items[i])
+		}
+		prompt := promptui.Select{
+			Label:  fmt.Sprintf("%s, %d selected (select \"Done\" when finished)", label, len(selected)),
+			Items:  options,
+			Size:   10,
+			Stdin:  reader.Stdin(),
+			Stdout: writer.Stdout(),
+		}
+		i, _, err := prompt.Run()
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			break
+		}
+		selected = append(selected, remaining[i-1])
+		remaining = append(remaining[:i-1], remaining[i:]...)
+	}
+	return selected, nil
+}
+
+// reads the commit authors of the git repository containing dir, ranks them and lets
+// the user select any number of them. Authors whose email is already in the existing
+// list are not offered. With --no-input all of the offered authors are selected.
+func selectFromGit(reader utils.Reader, writer utils.Writer, dir string, existing any, label string, input *utils.Input) ([]any, error) {
+	identities, err := gitlog.Log(dir, gitSince, gitUntil)
+	if err != nil {
+		return nil, err
+	}
+	err = gitlog.Sort(identities, gitSort)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := existingEmails(existing)
+	candidates := []gitlog.Identity{}
+	for _, identity := range identities {
+		if !emails[strings.ToLower(identity.Email)] {
+			candidates = append(candidates, identity)
+		}
+	}
+
+	selected := []any{}
+	if input.IsNoInput() {
+		for _, candidate := range candidates {
+			selected = append(selected, *candidate.Person())
+		}
+		return selected, nil
+	}
+
+	indexes, err := selectMany(reader, writer, "Select a "+strings.ToLower(label)+" to add", labels(candidates))
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range indexes {
+		selected = append(selected, *candidates[i].Person())
+	}
+	return selected, nil
+}
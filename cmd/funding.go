@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func validGrantIdentifier(identifier string) error {
+	if strings.TrimSpace(identifier) == "" {
+		return fmt.Errorf("a grant identifier is required")
+	}
+	return nil
+}
+
+// returns the grant of the funding value with the given identifier, if any
+func findGrant(value any, identifier string) map[string]any {
+	for _, item := range appendAll(value, nil) {
+		if grant, ok := item.(map[string]any); ok && grant[model.Identifier] == identifier {
+			return grant
+		}
+	}
+	return nil
+}
+
+// adds a CodeMeta 3.0 funding entry, a Grant with its identifier, name and funder
+// organization, to the in-progress codemeta.json file
+func funding(reader utils.Reader, writer utils.Writer, basedir string, input *utils.Input) (*map[string]any, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	stdin := reader.Stdin()
+	stdout := writer.Stdout()
+	funder, err := utils.NewPartyPrompt(&reader, &writer, "Funder", input.WithPrefix("funder-"), savedPeople(basedir), false, true)
+	if err != nil {
+		return nil, err
+	}
+	if id, ok := (*funder)[model.Id].(string); ok && id != "" {
+		ror, err := utils.NormalizeRorId(id)
+		if err != nil {
+			return nil, writer.Errorf("invalid funder identifier: %s", err.Error())
+		}
+		(*funder)[model.Id] = ror
+	}
+	identifier, err := input.Prompt(&stdin, &stdout, "identifier", "Enter the identifier (number) of the grant", validGrantIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	name, err := input.Prompt(&stdin, &stdout, "name", "Enter the name of the grant", utils.Nop)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _ := doc.Value(model.Funding)
+	if findGrant(existing, *identifier) != nil {
+		return nil, writer.Errorf("a grant with the identifier %s has already been added", *identifier)
+	}
+	grant := model.NewGrant(identifier, name, *funder)
+	err = doc.Set(model.Funding, appendAll(existing, []any{*grant}))
+	if err == nil {
+		err = saveInProgressDocument(inProgressFilePath, doc)
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	} else {
+		writer.Println("⭐ Successfully updated in-progress codemeta.json file.")
+	}
+	return grant, nil
+}
+
+// fundingCmd represents the funding command
+var fundingCmd = &cobra.Command{
+	Use:   "funding",
+	Args:  cobra.NoArgs,
+	Short: "Adds a grant to the funding of the in-progress codemeta.json file",
+	Long: `
+Add a grant to the 'funding' of the in-progress codemeta.json file.
+
+Prompts for the funder organization, the identifier (number) of the grant and its
+name and appends a CodeMeta 3.0 Grant to the 'funding' array, e.g.
+
+{
+  "@type": "Grant",
+  "identifier": "1234567",
+  "name": "Research Software Sustainability",
+  "funder": {
+    "@type": "Organization",
+    "@id": "https://ror.org/021nxhr62",
+    "name": "National Science Foundation",
+    "url": "https://www.nsf.gov"
+  }
+}
+
+The identifier of the funder is optional, a ROR ID (see: https://ror.org) given
+without the "https://ror.org/" prefix is expanded to its URL. Saved organizations
+from the address book are offered as funders.
+
+You can add multiple grants by running this command multiple times. To list a funder
+without a grant, run the "add funder" command instead.
+
+When complete, run "generate" to generate the resultant 'codemeta.json' file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := funding(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, inputFromFlags(cmd))
+		return err
+	},
+}
+
+func init() {
+	addCmd.AddCommand(fundingCmd)
+
+	addOrganizationFlags(fundingCmd, "funder-", "funder")
+	fundingCmd.Flags().String("identifier", "", "identifier (number) of the grant")
+	fundingCmd.Flags().String("name", "", "name of the grant")
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestFunding(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{model.Name: "name", model.Funding: "Legacy funding text"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	input := &utils.Input{Values: map[string]string{
+		"funder-org-name": "National Science Foundation",
+		"funder-url":      "https://www.nsf.gov",
+		"funder-org-id":   "021nxhr62",
+		"identifier":      "1234567",
+		"name":            "Research Software Sustainability",
+	}}
+	grant, err := funding(&reader, &writer, temp, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*grant)[model.Type]).Should(gomega.Equal(model.GrantType))
+	g.Ω((*grant)[model.Funder].(map[string]any)[model.Id]).Should(gomega.Equal("https://ror.org/021nxhr62"))
+
+	// the same grant cannot be added twice
+	_, err = funding(&reader, &writer, temp, input)
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Funding]).Should(gomega.HaveLen(2))
+
+	bytes, _ := utils.LoadFile(inProgressFilePath)
+	g.Expect(cue.Validate(bytes)).To(gomega.BeNil())
+}
+
+func TestFundingInvalid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	err := utils.Marshal(utils.GetInProgressFilePath(temp), *model.NewCodemeta(&map[string]any{model.Name: "name"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	// a funder must be an organization
+	_, err = funding(&reader, &writer, temp, &utils.Input{Values: map[string]string{"funder-given-name": "Jane", "identifier": "1"}})
+	g.Expect(err).ToNot(gomega.BeNil())
+	// an invalid ROR ID
+	_, err = funding(&reader, &writer, temp, &utils.Input{Values: map[string]string{"funder-org-name": "Org", "funder-url": "https://org.org", "funder-org-id": "https://ror.org/invalid", "identifier": "1", "name": ""}})
+	g.Expect(err).ToNot(gomega.BeNil())
+	// the grant identifier is required
+	_, err = funding(&reader, &writer, temp, &utils.Input{Values: map[string]string{"funder-org-name": "Org", "funder-url": "https://org.org", "funder-org-id": "", "identifier": " ", "name": ""}, NoInput: true})
+	g.Expect(err).ToNot(gomega.BeNil())
+}
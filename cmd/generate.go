@@ -1,54 +1,135 @@
 package cmd
 
 import (
-	"github.com/cacoco/codemetagenerator/internal/cue"
+	"fmt"
+	"strings"
+
 	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/cacoco/codemetagenerator/pkg/codemeta"
 	"github.com/spf13/cobra"
 )
 
-func generate(basedir string, writer utils.Writer, outFile string) error {
-	inProgressFilePath := utils.GetInProgressFilePath(basedir)
+// the empty values of the in-progress file are removed, see removeEmpty, and the keys of
+// the output are in the order, see utils.Orders. The output file is formatted as the
+// .editorconfig files of its git repository specify, the options, e.g., of the formatting
+// flags, are applied to the format and may be nil.
+func generate(basedir string, writer utils.Writer, outFile string, order string, options func(utils.Format) utils.Format) error {
+	err := utils.ValidOrder(order)
+	if err != nil {
+		return writer.Errorf(err.Error())
+	}
+	inProgressFilePath := getInProgressFilePath(basedir)
 
-	json, err := utils.ReadJSON(inProgressFilePath)
+	bytes, err := utils.LoadFile(inProgressFilePath)
 	if err != nil {
 		handleErr(writer, err)
 		return writer.Errorf("unable to read codemeta.inprogress.json file, ensure you have run `codemetagenerator new` at least once")
 	}
 
+	bytes, err = removeEmpty(bytes)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to parse codemeta.inprogress.json file: %v", err)
+	}
+
 	// ensure the codemeta file is valid
-	err = cue.Validate([]byte(*json))
+	doc, err := codemeta.Parse(bytes)
+	if err == nil {
+		err = doc.Validate()
+	}
 	if err != nil {
 		handleErr(writer, err)
 		return writer.Errorf("invalid codemeta.json file: %v", err)
 	}
 
+	format := utils.DefaultFormat
 	if outFile != "" {
-		err = utils.WriteJSON(outFile, *json)
+		format = utils.FormatOf(outFile)
+	}
+	if options != nil {
+		format = options(format)
+	}
+	json, err := format.JSON(bytes, order)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to format codemeta.json file: %v", err)
+	}
+
+	if outFile != "" {
+		err = utils.WriteJSON(outFile, json)
 		if err != nil {
 			handleErr(writer, err)
 			return writer.Errorf("unable to write codemeta.json file to output file %s", outFile)
 		}
+	} else if format.FinalNewline {
+		writer.Print(json)
 	} else {
-		writer.Println(*json)
-
+		writer.Println(json)
 	}
 	return nil
 }
 
 var outputFile string
+var outputOrder string
+var outputIndent int
+var outputTabs bool
+var outputMinify bool
+var outputFinalNewline bool
+var outputCRLF bool
+
+// returns the options of the formatting flags given on the command line, which override
+// the format of the output
+func formatOptions(cmd *cobra.Command) func(utils.Format) utils.Format {
+	return func(format utils.Format) utils.Format {
+		flags := cmd.Flags()
+		if flags.Changed("indent") {
+			format.IndentSize = outputIndent
+			format.Tabs = false
+		}
+		if flags.Changed("tabs") {
+			format.Tabs = outputTabs
+		}
+		if flags.Changed("minify") {
+			format.Minify = outputMinify
+		}
+		if flags.Changed("final-newline") {
+			format.FinalNewline = outputFinalNewline
+		}
+		if flags.Changed("crlf") {
+			format.CRLF = outputCRLF
+		}
+		return format
+	}
+}
 
 // generateCmd represents the generate command
 var generateCmd = &cobra.Command{
-	Use:   "generate [-o | --output <path/to/codemeta.json>]",
+	Use:   "generate [-o | --output <path/to/codemeta.json>] [--order canonical|alpha|preserve] [--indent <n> | --tabs | --minify] [--final-newline] [--crlf]",
 	Args:  cobra.NoArgs,
 	Short: "Generate the resultant 'codemeta.json' file to the optional output file or to the console",
 	Long: `
 Generate the resultant 'codemeta.json' file from the in-progress file. 
 
 Output can be written to a file [-o | --output  <path/to/codemeta.json>] or 
-printed to the console.`,
+printed to the console.
+
+The keys are written in canonical CodeMeta order: @context, @type, identifier, name and
+description, followed by the other properties grouped by what the software is, where it
+is, its license, its releases, what it runs on and its people. Properties without a
+canonical position follow in alphabetical order. Use --order alpha for alphabetical
+order, or --order preserve to keep the order of the in-progress file, which keeps the key
+order of a file loaded with "new --input".
+
+The output is indented by two spaces without a final newline. When the output file is
+inside a git repository, the indent_style, indent_size, insert_final_newline and
+end_of_line properties of the .editorconfig files of the repository matching the output
+file are applied. The --indent, --tabs, --minify, --final-newline and --crlf flags take
+precedence over them.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return generate(utils.UserHomeDir, &utils.StdoutWriter{}, outputFile)
+		if outputIndent < 0 {
+			return fmt.Errorf("invalid value for --indent: %d, must not be negative", outputIndent)
+		}
+		return generate(utils.UserHomeDir, &utils.StdoutWriter{}, outputFile, outputOrder, formatOptions(cmd))
 	},
 }
 
@@ -56,4 +137,11 @@ func init() {
 	rootCmd.AddCommand(generateCmd)
 
 	generateCmd.Flags().StringVarP(&outputFile, "output", "o", "", "path to the output 'codemeta.json' file. If not specified, the output will be printed to the console.")
+	generateCmd.Flags().StringVar(&outputOrder, "order", utils.OrderCanonical, "order of the keys of the output, one of: "+strings.Join(utils.Orders, ", "))
+	generateCmd.Flags().IntVar(&outputIndent, "indent", utils.DefaultFormat.IndentSize, "number of spaces to indent the output by")
+	generateCmd.Flags().BoolVar(&outputTabs, "tabs", false, "indent the output with tabs instead of spaces")
+	generateCmd.Flags().BoolVar(&outputMinify, "minify", false, "write the output on a single line without spaces")
+	generateCmd.Flags().BoolVar(&outputFinalNewline, "final-newline", false, "end the output with a newline")
+	generateCmd.Flags().BoolVar(&outputCRLF, "crlf", false, "end the lines of the output with CRLF (\\r\\n) instead of LF (\\n)")
+	generateCmd.MarkFlagsMutuallyExclusive("indent", "tabs", "minify")
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/cacoco/codemetagenerator/internal/model"
@@ -37,7 +38,7 @@ func Test_ExecuteGenerateCmd(t *testing.T) {
 
 	tempOutputFilePath := temp + "/codemeta.json"
 	generate := &cobra.Command{Use: "generate", RunE: func(cmd *cobra.Command, args []string) error {
-		return generate(temp, writer, tempOutputFilePath)
+		return generate(temp, writer, tempOutputFilePath, utils.OrderCanonical, nil)
 	},
 	}
 	buf := bytes.NewBufferString("")
@@ -91,7 +92,7 @@ func Test_ExecuteGenerateCmdBadFile(t *testing.T) {
 	writer := &utils.TestWriter{}
 
 	generate := &cobra.Command{Use: "generate", RunE: func(cmd *cobra.Command, args []string) error {
-		return generate(temp, writer, "")
+		return generate(temp, writer, "", utils.OrderCanonical, nil)
 	},
 	}
 	buf := bytes.NewBufferString("")
@@ -104,3 +105,89 @@ func Test_ExecuteGenerateCmdBadFile(t *testing.T) {
 		t.Errorf("Expected error")
 	}
 }
+
+func TestGenerateOrder(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+
+	// the in-progress file of "new --input" keeps the order of the input file
+	err := utils.WriteJSON(utils.GetInProgressFilePath(temp), `{
+  "name": "TestMeta",
+  "author": [{"email": "jane@example.org", "@type": "Person", "givenName": "Jane"}],
+  "@type": "SoftwareSourceCode",
+  "codeRepository": "https://github.com/org/testmeta?a=1&b=2",
+  "description": "A test codemeta.json file.",
+  "@context": "https://w3id.org/codemeta/3.0",
+  "identifier": "testmeta"
+}`)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	writer := &utils.TestWriter{}
+	output := temp + "/codemeta.json"
+	keys := func() []string {
+		bytes, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return regexp.MustCompile(`(?m)^  "([^"]+)"`).FindAllString(string(bytes), -1)
+	}
+
+	err = generate(temp, writer, output, utils.OrderCanonical, nil)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(keys()).Should(gomega.Equal([]string{`  "@context"`, `  "@type"`, `  "identifier"`, `  "name"`, `  "description"`, `  "codeRepository"`, `  "author"`}))
+	bytes, _ := os.ReadFile(output)
+	g.Ω(string(bytes)).Should(gomega.ContainSubstring(`"@type": "Person",
+      "givenName": "Jane",
+      "email": "jane@example.org"`))
+	g.Ω(string(bytes)).Should(gomega.ContainSubstring(`a=1&b=2`))
+
+	err = generate(temp, writer, output, utils.OrderAlpha, nil)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(keys()).Should(gomega.Equal([]string{`  "@context"`, `  "@type"`, `  "author"`, `  "codeRepository"`, `  "description"`, `  "identifier"`, `  "name"`}))
+
+	err = generate(temp, writer, output, utils.OrderPreserve, nil)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(keys()).Should(gomega.Equal([]string{`  "name"`, `  "author"`, `  "@type"`, `  "codeRepository"`, `  "description"`, `  "@context"`, `  "identifier"`}))
+
+	err = generate(temp, writer, output, "random", nil)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestGenerateFormat(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	err := utils.WriteJSON(utils.GetInProgressFilePath(temp), `{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", "keywords": ["a"]}`)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// a repository whose .editorconfig indents JSON files with tabs
+	repo := t.TempDir()
+	os.Mkdir(repo+"/.git", 0755)
+	err = os.WriteFile(repo+"/.editorconfig", []byte("root = true\n\n[*.json]\nindent_style = tab\ninsert_final_newline = true\n"), 0644)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	output := repo + "/codemeta.json"
+	writer := &utils.TestWriter{}
+
+	err = generate(temp, writer, output, utils.OrderCanonical, nil)
+	g.Expect(err).To(gomega.BeNil())
+	bytes, _ := os.ReadFile(output)
+	g.Ω(string(bytes)).Should(gomega.Equal("{\n\t\"@context\": \"https://w3id.org/codemeta/3.0\",\n\t\"@type\": \"SoftwareSourceCode\",\n\t\"keywords\": [\n\t\t\"a\"\n\t]\n}\n"))
+
+	// the flags take precedence over the .editorconfig file
+	err = generate(temp, writer, output, utils.OrderCanonical, func(format utils.Format) utils.Format {
+		format.Minify = true
+		format.CRLF = true
+		return format
+	})
+	g.Expect(err).To(gomega.BeNil())
+	bytes, _ = os.ReadFile(output)
+	g.Ω(string(bytes)).Should(gomega.Equal("{\"@context\":\"https://w3id.org/codemeta/3.0\",\"@type\":\"SoftwareSourceCode\",\"keywords\":[\"a\"]}\r\n"))
+}
@@ -9,32 +9,26 @@ import (
 )
 
 func keyword(writer utils.Writer, basedir string, args []string) ([]any, error) {
-	inProgressFilePath := utils.GetInProgressFilePath(basedir)
+	inProgressFilePath := getInProgressFilePath(basedir)
 
-	codemeta, err := utils.Unmarshal(inProgressFilePath)
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
 	if err != nil {
-		handleErr(writer, err)
-		return nil, writer.Errorf("unable to load the in-progress codemeta.json file for editing. Have you run \"codemetagenerator new\" yet?")
+		return nil, err
 	}
-	mutateMap := *codemeta
-	currentValue := mutateMap[model.Keywords]
-	if currentValue == nil {
-		var arr []any = make([]any, len(args))
-		// need to copy into an	array of any type
-		for i, v := range args {
-			arr[i] = v
-		}
-		mutateMap[model.Keywords] = arr
-	} else {
-		for _, keyword := range args {
-			// since we're ranging over the args, we need to ensure we're appending to the latest value in the map for model.Keywords key
-			mutateMap[model.Keywords] = append(mutateMap[model.Keywords].([]any), keyword)
-		}
+	currentValue, _ := doc.Value(model.Keywords)
+	values := make([]any, len(args))
+	// need to copy into an array of any type
+	for i, v := range args {
+		values[i] = v
+	}
+	keywords := appendAll(currentValue, values)
+	err = doc.Set(model.Keywords, keywords)
+	if err != nil {
+		return nil, writer.Errorf("unable to add keyword(s): %s", err.Error())
 	}
 	writer.Println("Added keyword(s): " + strings.Join(args, ", "))
-	keywords := mutateMap[model.Keywords].([]any)
 
-	err = utils.Marshal(inProgressFilePath, mutateMap)
+	err = saveInProgressDocument(inProgressFilePath, doc)
 	if err != nil {
 		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing: %s", err.Error())
 	} else {
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/languages"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/cacoco/codemetagenerator/pkg/codemeta"
+	"github.com/spf13/cobra"
+)
+
+var detectLanguages bool
+
+// returns the name of a programming language, either a ComputerLanguage or its name
+func languageName(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		name, _ := v[model.Name].(string)
+		return name
+	}
+	return ""
+}
+
+// adds the languages to the programmingLanguage list, skipping the languages whose name
+// is already listed. Returns the added languages.
+func putLanguages(doc *codemeta.SoftwareSourceCode, entries []map[string]any) ([]map[string]any, error) {
+	existing, _ := doc.Value(model.ProgrammingLanguage)
+	list := appendAll(existing, nil)
+	names := map[string]bool{}
+	for _, item := range list {
+		names[strings.ToLower(languageName(item))] = true
+	}
+	added := []map[string]any{}
+	for _, entry := range entries {
+		name := strings.ToLower(languageName(entry))
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		list = append(list, entry)
+		added = append(added, entry)
+	}
+	if len(added) > 0 {
+		err := doc.Set(model.ProgrammingLanguage, list)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return added, nil
+}
+
+// adds the languages to the in-progress codemeta.json file
+func saveLanguages(writer utils.Writer, basedir string, entries func(doc *codemeta.SoftwareSourceCode) ([]map[string]any, error)) ([]map[string]any, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := entries(doc)
+	if err != nil {
+		return nil, err
+	}
+	added, err := putLanguages(doc, selected)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to add the programming language(s)")
+	}
+	names := []string{}
+	for _, entry := range added {
+		names = append(names, languageName(entry))
+	}
+	if len(names) == 0 {
+		writer.Println("No programming languages were added.")
+		return added, nil
+	}
+	writer.Println("Added programming language(s): " + strings.Join(names, ", "))
+
+	err = saveInProgressDocument(inProgressFilePath, doc)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	} else {
+		writer.Println("⭐ Successfully updated in-progress codemeta.json file.")
+	}
+	return added, nil
+}
+
+// adds a single programming language, prompting for its name and URL
+func language(reader utils.Reader, writer utils.Writer, basedir string, input *utils.Input) ([]map[string]any, error) {
+	return saveLanguages(writer, basedir, func(doc *codemeta.SoftwareSourceCode) ([]map[string]any, error) {
+		stdin := reader.Stdin()
+		stdout := writer.Stdout()
+		name, err := input.Prompt(&stdin, &stdout, "name", "Enter the name of the programming language", validLanguageName)
+		if err != nil {
+			return nil, err
+		}
+		url, err := input.Prompt(&stdin, &stdout, "url", "Enter the URL of the programming language", utils.ValidUrl)
+		if err != nil {
+			return nil, err
+		}
+		return []map[string]any{*model.NewProgrammingLanguage(name, url)}, nil
+	})
+}
+
+func validLanguageName(name string) error {
+	if name == "" {
+		return fmt.Errorf("a name is required")
+	}
+	return nil
+}
+
+// detects the programming languages of the files in dir, ranked by their number of
+// bytes, and lets the user select the languages to add. With --no-input all of the
+// detected languages are added.
+func languagesFromFiles(reader utils.Reader, writer utils.Writer, basedir string, dir string, input *utils.Input) ([]map[string]any, error) {
+	return saveLanguages(writer, basedir, func(doc *codemeta.SoftwareSourceCode) ([]map[string]any, error) {
+		usages, err := languages.Detect(dir)
+		if err != nil {
+			handleErr(writer, err)
+			return nil, writer.Errorf("unable to detect the programming languages in %s", dir)
+		}
+		existing := map[string]bool{}
+		listed, _ := doc.Value(model.ProgrammingLanguage)
+		for _, item := range appendAll(listed, nil) {
+			existing[strings.ToLower(languageName(item))] = true
+		}
+
+		entries := []map[string]any{}
+		items := []string{}
+		for _, usage := range usages {
+			if existing[strings.ToLower(usage.Name)] {
+				continue
+			}
+			entries = append(entries, usage.Entry())
+			items = append(items, fmt.Sprintf("%s (%.1f%%, %d bytes)", usage.Name, languages.Share(usage, usages), usage.Bytes))
+		}
+		if input.IsNoInput() {
+			return entries, nil
+		}
+		indexes, err := selectMany(reader, writer, "Select a programming language to add", items)
+		if err != nil {
+			return nil, err
+		}
+		selected := []map[string]any{}
+		for _, i := range indexes {
+			selected = append(selected, entries[i])
+		}
+		return selected, nil
+	})
+}
+
+// languageCmd represents the language command
+var languageCmd = &cobra.Command{
+	Use:   "language",
+	Args:  cobra.NoArgs,
+	Short: "Adds a programming language to the in-progress codemeta.json file",
+	Long: `
+Add a programming language to the 'programmingLanguage' list of the in-progress
+codemeta.json file. Prompts for the name and URL of the language and appends it as a
+ComputerLanguage. A language whose name is already listed is skipped.
+
+Use --detect to detect the languages of the project instead. The files in the working
+directory are scanned, skipping hidden directories and directories of dependencies and
+build outputs, e.g., node_modules, vendor or target. The languages are recognized by
+the extensions of the files and ranked by their number of bytes. Select any number of
+them to add, with --no-input all of them are added.
+
+When complete, run "generate" to generate the resultant 'codemeta.json' file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if detectLanguages {
+			workingDir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			_, err = languagesFromFiles(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, workingDir, inputFromFlags(cmd))
+			return err
+		}
+		_, err := language(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, inputFromFlags(cmd))
+		return err
+	},
+}
+
+// adds the runtime platforms to the runtimePlatform of the in-progress codemeta.json file,
+// skipping the platforms which are already listed. Returns the added platforms.
+func runtimePlatform(writer utils.Writer, basedir string, args []string) ([]string, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _ := doc.Value(model.RuntimePlatform)
+	platforms := appendAll(existing, nil)
+	added := []string{}
+	for _, arg := range args {
+		found := false
+		for _, platform := range platforms {
+			if platform == arg {
+				found = true
+			}
+		}
+		if !found {
+			platforms = append(platforms, arg)
+			added = append(added, arg)
+		}
+	}
+	if len(added) == 0 {
+		writer.Println("No runtime platforms were added.")
+		return added, nil
+	}
+	err = doc.Set(model.RuntimePlatform, platforms)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to add the runtime platform(s)")
+	}
+	writer.Println("Added runtime platform(s): " + strings.Join(added, ", "))
+
+	err = saveInProgressDocument(inProgressFilePath, doc)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	} else {
+		writer.Println("⭐ Successfully updated in-progress codemeta.json file.")
+	}
+	return added, nil
+}
+
+// runtimePlatformCmd represents the runtime-platform command
+var runtimePlatformCmd = &cobra.Command{
+	Use:     "runtime-platform",
+	Aliases: []string{model.RuntimePlatform},
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Adds runtime platforms to the in-progress codemeta.json file",
+	Long: `
+Add one or more runtime platforms, e.g., "Java 17" or "Node.js 20", to the
+'runtimePlatform' list of the in-progress codemeta.json file. Platforms which are
+already listed are skipped.
+
+codemetagenerator add runtime-platform "Node.js 20" "Deno 1.40"
+
+When complete, run "generate" to generate the resultant 'codemeta.json' file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := runtimePlatform(&utils.StdoutWriter{}, utils.UserHomeDir, args)
+		return err
+	},
+}
+
+func init() {
+	addCmd.AddCommand(languageCmd)
+	addCmd.AddCommand(runtimePlatformCmd)
+
+	languageCmd.Flags().BoolVar(&detectLanguages, "detect", false, "detect the programming languages of the files in the working directory")
+	languageCmd.Flags().String("name", "", "name of the programming language")
+	languageCmd.Flags().String("url", "", "URL of the programming language")
+}
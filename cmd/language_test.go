@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestLanguage(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	// a single programming language as written by "new"
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{
+		model.Name:                "name",
+		model.ProgrammingLanguage: map[string]any{model.Type: model.ComputerLanguageType, model.Name: "Go", model.URL: "https://go.dev"},
+	}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	added, err := language(&reader, &writer, temp, &utils.Input{Values: map[string]string{"name": "SQL", "url": "https://www.iso.org/standard/76583.html"}})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(added).Should(gomega.HaveLen(1))
+
+	// detect the languages of a working tree, Go is already listed
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "app.ts"), []byte("export {}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "build.py"), []byte("print()\n"), 0644)
+
+	var stack utils.Stack[string]
+	stack.Push("\n")  // done
+	stack.Push("j\n") // TypeScript
+	reader = utils.TestReader{In: utils.TestStdin{Data: stack}}
+	added, err = languagesFromFiles(&reader, &writer, temp, dir, nil)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(added).Should(gomega.HaveLen(1))
+	g.Ω(added[0][model.Name]).Should(gomega.Equal("TypeScript"))
+
+	// all of the remaining languages are added without prompting
+	added, err = languagesFromFiles(&reader, &writer, temp, dir, &utils.Input{NoInput: true})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(added).Should(gomega.HaveLen(1))
+	g.Ω(added[0][model.Name]).Should(gomega.Equal("Python"))
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.ProgrammingLanguage]).Should(gomega.HaveLen(4))
+
+	bytes, _ := utils.LoadFile(inProgressFilePath)
+	g.Expect(cue.Validate(bytes)).To(gomega.BeNil())
+}
+
+func TestRuntimePlatform(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{model.Name: "name", model.RuntimePlatform: "go1.21"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	writer := utils.TestWriter{}
+
+	added, err := runtimePlatform(&writer, temp, []string{"Node.js 20", "go1.21", "Node.js 20"})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// the platforms which are already listed are skipped
+	g.Ω(added).Should(gomega.Equal([]string{"Node.js 20"}))
+	m, err := utils.Unmarshal(inProgressFilePath)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω((*m)[model.RuntimePlatform]).Should(gomega.Equal([]any{"go1.21", "Node.js 20"}))
+
+	added, err = runtimePlatform(&writer, temp, []string{"go1.21"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(added).Should(gomega.BeEmpty())
+
+	bytes, _ := utils.LoadFile(inProgressFilePath)
+	g.Expect(cue.Validate(bytes)).To(gomega.BeNil())
+
+	g.Ω(runtimePlatformValue("go1.21")).Should(gomega.Equal("go1.21"))
+	g.Ω(runtimePlatformValue("go1.21, Node.js 20")).Should(gomega.Equal([]any{"go1.21", "Node.js 20"}))
+	g.Ω(runtimePlatformValue("")).Should(gomega.Equal(""))
+}
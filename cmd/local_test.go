@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func resetProjectFile() {
+	ProjectFile = ""
+	Local = false
+	Draft = ""
+}
+
+func TestResolveProjectFileFromGitRoot(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer resetProjectFile()
+
+	temp := t.TempDir()
+	os.Mkdir(filepath.Join(temp, ".git"), 0755)
+	subdir := filepath.Join(temp, "sub", "dir")
+	os.MkdirAll(subdir, 0755)
+	err := utils.WriteJSON(filepath.Join(temp, utils.ProjectFileName), `{}`)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	Local = true
+	err = resolveProjectFile(subdir)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(ProjectFile).Should(gomega.Equal(filepath.Join(temp, utils.ProjectFileName)))
+	g.Ω(getInProgressFilePath(temp)).Should(gomega.Equal(ProjectFile))
+}
+
+func TestResolveProjectFileNotFound(t *testing.T) {
+	defer resetProjectFile()
+
+	Local = true
+	err := resolveProjectFile(t.TempDir())
+	if err == nil {
+		t.Errorf("Expected error")
+	}
+}
+
+func TestResolveProjectFileWithDraft(t *testing.T) {
+	defer resetProjectFile()
+
+	ProjectFile = "codemeta.json"
+	Draft = "draft"
+	err := resolveProjectFile(t.TempDir())
+	if err == nil {
+		t.Errorf("Expected error")
+	}
+}
+
+func TestSetProjectLocal(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer resetProjectFile()
+
+	temp := t.TempDir()
+	ProjectFile = filepath.Join(temp, utils.ProjectFileName)
+	err := utils.Marshal(ProjectFile, *model.NewCodemeta(&map[string]any{model.Name: "name"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	err = set(temp, []string{model.Version, "1.0.0"})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	m, err := utils.Unmarshal(ProjectFile)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Version]).Should(gomega.Equal("1.0.0"))
+
+	// invalid values are rejected and the file is left untouched
+	err = set(temp, []string{model.CodeRepository, "not a url"})
+	g.Expect(err).ToNot(gomega.BeNil())
+	m, err = utils.Unmarshal(ProjectFile)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.CodeRepository]).Should(gomega.BeNil())
+}
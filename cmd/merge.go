@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cacoco/codemetagenerator/internal/merge"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/ohler55/ojg/oj"
+	"github.com/spf13/cobra"
+)
+
+func loadDocument(writer utils.Writer, path string) (map[string]any, error) {
+	bytes, err := utils.LoadFile(path)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to read file %s", path)
+	}
+	var m map[string]any
+	err = oj.Unmarshal(bytes, &m)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to parse file %s as a JSON object", path)
+	}
+	return m, nil
+}
+
+func mergeFiles(writer utils.Writer, baseFile string, otherFile string, strategy string, outFile string) ([]merge.Conflict, error) {
+	err := merge.ValidStrategy(strategy)
+	if err != nil {
+		return nil, err
+	}
+	base, err := loadDocument(writer, baseFile)
+	if err != nil {
+		return nil, err
+	}
+	other, err := loadDocument(writer, otherFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result, conflicts, err := merge.Merge(base, other, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	json := utils.FormatJSON(result)
+	if outFile != "" {
+		err = utils.WriteJSON(outFile, json)
+		if err != nil {
+			handleErr(writer, err)
+			return nil, writer.Errorf("unable to write merged codemeta.json file to output file %s", outFile)
+		}
+	} else {
+		writer.Println(json)
+	}
+
+	// the report goes to stderr so that the merged output can be redirected
+	if len(conflicts) > 0 {
+		fmt.Fprintf(writer.StdErr(), "⚠️  %d conflicting properties, resolved with the '%s' strategy:\n", len(conflicts), strategy)
+		for _, conflict := range conflicts {
+			fmt.Fprintf(writer.StdErr(), "\t%s: %s (ours) <> %s (theirs) => %s\n",
+				conflict.Path,
+				oj.JSON(conflict.Ours),
+				oj.JSON(conflict.Theirs),
+				oj.JSON(conflict.Resolved))
+		}
+	}
+	return conflicts, nil
+}
+
+var mergeStrategy string
+var mergeOutputFile string
+
+// mergeCmd represents the merge command
+var mergeCmd = &cobra.Command{
+	Use:   "merge <base.json> <other.json> [-s | --strategy ours|theirs|union-arrays] [-o | --output <path/to/codemeta.json>]",
+	Args:  cobra.ExactArgs(2),
+	Short: "Merge two codemeta.json files, reporting conflicting properties",
+	Long: `
+Merge two codemeta.json files, e.g., a hand-written file and a file generated from
+project manifests, into a single file.
+
+Persons and organizations are de-duplicated by their "@id" or email and keywords are
+unioned ignoring case. Any other conflicts are resolved with the given strategy:
+
+	ours          conflicting values and arrays are taken from <base.json> (default)
+	theirs        conflicting values and arrays are taken from <other.json>
+	union-arrays  conflicting values are taken from <base.json>, arrays are unioned
+
+The conflicting scalar properties are reported on stderr. Output can be written to a
+file [-o | --output <path/to/codemeta.json>] or printed to the console.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := mergeFiles(&utils.StdoutWriter{}, args[0], args[1], mergeStrategy, mergeOutputFile)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().StringVarP(&mergeStrategy, "strategy", "s", merge.Ours, "strategy used to resolve conflicts: ours, theirs or union-arrays")
+	mergeCmd.Flags().StringVarP(&mergeOutputFile, "output", "o", "", "path to the output 'codemeta.json' file. If not specified, the output will be printed to the console.")
+}
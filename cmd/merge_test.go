@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/merge"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestMergeFiles(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	baseFile := temp + "/base.json"
+	otherFile := temp + "/other.json"
+	outFile := temp + "/codemeta.json"
+	utils.WriteJSON(baseFile, `{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", "name": "base", "keywords": ["Go"]}`)
+	utils.WriteJSON(otherFile, `{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", "name": "other", "keywords": ["go", "cli"]}`)
+
+	writer := utils.TestWriter{}
+	conflicts, err := mergeFiles(&writer, baseFile, otherFile, merge.Theirs, outFile)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(conflicts).Should(gomega.HaveLen(1))
+
+	m, err := utils.Unmarshal(outFile)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*m).Should(gomega.Equal(map[string]any{
+		model.Context:  model.DefaultContext,
+		model.Type:     model.SoftwareSourceCodeType,
+		model.Name:     "other",
+		model.Keywords: []any{"Go", "cli"},
+	}))
+}
+
+func TestMergeFilesMissingFile(t *testing.T) {
+	writer := utils.TestWriter{}
+	_, err := mergeFiles(&writer, "nonexistentfile", "nonexistentfile", merge.Ours, "")
+	if err == nil {
+		t.Errorf("Expected error")
+	}
+}
@@ -3,120 +3,133 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
+	"github.com/cacoco/codemetagenerator/internal/config"
 	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/repository"
 	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/cacoco/codemetagenerator/pkg/codemeta"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
-func new(basedir string, reader utils.Reader, writer utils.Writer, inFile string) error {
+// the code repository, issue tracker and continuous integration URLs are inferred from the
+// git repository containing the working directory, if any. When fromGit is set the authors
+// are selected from its commit authors.
+func new(basedir string, reader utils.Reader, writer utils.Writer, inFile string, workingDir string, fromGit bool, input *utils.Input) error {
 	stdin := reader.Stdin()
 	stdout := writer.Stdout()
 
+	if isProjectLocal() {
+		return writer.Errorf("\"new\" cannot be run in project-local mode as it would replace %s, use \"set\" or \"add\" to edit it in place", ProjectFile)
+	}
+
 	// clean up any previous file
-	inProgressFilePath := utils.GetInProgressFilePath(basedir)
+	inProgressFilePath := getInProgressFilePath(basedir)
 	utils.DeleteFile(inProgressFilePath)
+	err := utils.MkDraftsDir(basedir)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to create the in-progress codemeta.json file")
+	}
 
 	var successMsg string = "⭐ Successfully created new in-progress codemeta.json file."
+	var doc *codemeta.SoftwareSourceCode
+	// the key order of the input file, if any
+	var template []byte
 	if inFile != "" {
 		bytes, err := utils.LoadFile(inFile)
 		if err != nil {
 			handleErr(writer, err)
 			return writer.Errorf("unable to read input file %s", inFile)
 		}
-		err = utils.WriteFile(inProgressFilePath, bytes)
+		doc, err = codemeta.Parse(bytes)
 		if err != nil {
 			handleErr(writer, err)
-			return writer.Errorf("unable to write in-progress codemeta.json file")
+			return writer.Errorf("unable to read input file %s", inFile)
 		}
+		template = bytes
 
 		fileBase := filepath.Base(inFile)
 		successMsg = fmt.Sprintf("⭐ Successfully loaded '%s' as new in-progress codemeta.json file.", fileBase)
 	} else {
-		var result = make(map[string]any)
+		builder := codemeta.NewBuilder().Context(model.ConfiguredContext)
 
-		identifier, err := utils.MkPrompt(&stdin, &stdout, "Enter a unique identifier for your software source code", utils.Nop)
+		identifier, err := input.Prompt(&stdin, &stdout, "identifier", "Enter a unique identifier for your software source code", utils.Nop)
 		if err != nil {
 			handleErr(writer, err)
-			return writer.Errorf("unable to create new identifier")
+			return writer.Errorf("unable to create new identifier: %s", err.Error())
 		}
 
-		name, err := utils.MkPrompt(&stdin, &stdout, "Enter a name for your software source code", utils.Nop)
+		name, err := input.Prompt(&stdin, &stdout, "name", "Enter a name for your software source code", utils.Nop)
 		if err != nil {
 			handleErr(writer, err)
-			return writer.Errorf("unable to create new name")
+			return writer.Errorf("unable to create new name: %s", err.Error())
 		}
 
-		description, err := utils.MkPrompt(&stdin, &stdout, "Enter a description for your software source code", utils.Nop)
+		description, err := input.Prompt(&stdin, &stdout, "description", "Enter a description for your software source code", utils.Nop)
 		if err != nil {
 			handleErr(writer, err)
-			return writer.Errorf("unable to create new description")
-		}
-
-		developmentStatusOptions := []model.MenuOption{
-			{Name: "Abandoned", Type: "abandoned"},
-			{Name: "Active", Type: "active"},
-			{Name: "Concept", Type: "concept"},
-			{Name: "Inactive", Type: "inactive"},
-			{Name: "Moved", Type: "moved"},
-			{Name: "Suspended", Type: "suspended"},
-			{Name: "Unsupported", Type: "unsupported"},
-			{Name: "WIP", Type: "wip"},
-		}
-
-		templates := &promptui.SelectTemplates{
-			Label:    "{{ . }}",
-			Active:   "➞ {{ .Name | cyan }}",
-			Inactive: "  {{ .Name | cyan }}",
-			Selected: `{{ "Select a development status (see: https://www.repostatus.org/):" | faint}} {{ .Name | faint }}`,
-			Details: `--------- Status ----------
-{{ "Name:" | faint }}	{{ .Name }}`,
+			return writer.Errorf("unable to create new description: %s", err.Error())
+		}
+
+		developmentStatus, err := developmentStatusPrompt(reader, writer, input)
+		if err != nil {
+			return err
 		}
 
-		prompt := promptui.Select{
-			Label:     "Select a development status from the list below (see: https://www.repostatus.org/)",
-			Items:     developmentStatusOptions,
-			Templates: templates,
-			Size:      8,
-			Searcher:  nil,
-			Stdin:     reader.Stdin(),
-			Stdout:    writer.Stdout(),
+		inferred := &repository.Inferred{}
+		if workingDir != "" {
+			if i, err := repository.Infer(workingDir); err == nil {
+				inferred = i
+			}
 		}
 
-		i, _, err := prompt.Run()
+		codeRepository, err := input.PromptInferred(&stdin, &stdout, "code-repository", "Enter the URL of the code repository for the project", inferred.CodeRepository, utils.ValidUrl)
 		if err != nil {
 			return err
 		}
-		developmentStatus := developmentStatusOptions[i].Name
 
-		codeRepository, err := utils.MkPrompt(&stdin, &stdout, "Enter the URL of the code repository for the project", utils.ValidUrl)
+		// only prompted for when given or inferred
+		if input.Has("issue-tracker") || inferred.IssueTracker != "" {
+			issueTracker, err := input.PromptInferred(&stdin, &stdout, "issue-tracker", "Enter the URL of the issue tracker for the project", inferred.IssueTracker, utils.ValidUrl)
+			if err != nil {
+				return err
+			}
+			builder.IssueTracker(*issueTracker)
+		}
+
+		continuousIntegration, err := continuousIntegrationPrompt(reader, writer, input, inferred.ContinuousIntegration)
 		if err != nil {
 			return err
 		}
+		if len(continuousIntegration) > 0 {
+			// named contIntegration in CodeMeta 2.0
+			builder.Set(model.ContinuousIntegrationOf(model.ConfiguredContext), continuousIntegration)
+		}
 
-		programmingLanguageName, err := utils.MkPrompt(&stdin, &stdout, "Enter the name of the programming language of the project", utils.Nop)
+		programmingLanguageName, err := input.Prompt(&stdin, &stdout, "language-name", "Enter the name of the programming language of the project", utils.Nop)
 		if err != nil {
 			return err
 		}
-		programmingLanguageURL, err := utils.MkPrompt(&stdin, &stdout, "Enter the URL of the programming language of the project", utils.ValidUrl)
+		programmingLanguageURL, err := input.Prompt(&stdin, &stdout, "language-url", "Enter the URL of the programming language of the project", utils.ValidUrl)
 		if err != nil {
 			return err
 		}
-		programmingLanguage := model.NewProgrammingLanguage(programmingLanguageName, programmingLanguageURL)
 
-		runtimePlatform, err := utils.MkPrompt(&stdin, &stdout, "Enter the name of the runtime platform of the project", utils.Nop)
+		runtimePlatformNames, err := input.Prompt(&stdin, &stdout, "runtime-platform", "Enter the name of the runtime platform of the project, separate multiple platforms with commas", utils.Nop)
 		if err != nil {
 			return err
 		}
 
-		version, err := utils.MkPrompt(&stdin, &stdout, "Enter the version of the project", utils.Nop)
+		version, err := input.Prompt(&stdin, &stdout, "version", "Enter the version of the project", utils.Nop)
 		if err != nil {
 			return err
 		}
 
 		validateFn := validateLicenseId(writer, basedir)
-		license, err := utils.MkPrompt(&stdin, &stdout, "Enter the SPDX license ID for the project (see: https://spdx.org/licenses/)", validateFn)
+		license, err := input.PromptWithDefault(&stdin, &stdout, "license", "Enter the SPDX license ID for the project (see: https://spdx.org/licenses/)", configured(config.License), validateFn)
 		if err != nil {
 			return err
 		}
@@ -131,37 +144,60 @@ func new(basedir string, reader utils.Reader, writer utils.Writer, inFile string
 			licenseDetailsUrl = *referenceUrl
 		}
 
-		readme, err := utils.MkPrompt(&stdin, &stdout, "Enter the URL of the README file for the project", utils.ValidUrl)
+		readme, err := input.Prompt(&stdin, &stdout, "readme", "Enter the URL of the README file for the project", utils.ValidUrl)
 		if err != nil {
 			return err
 		}
 
-		maintainer, err := utils.NewPersonOrOrganizationPrompt(&reader, &writer, "Maintainer")
+		maintainer, err := utils.NewPersonOrOrganizationPrompt(&reader, &writer, "Maintainer", input.WithPrefix("maintainer-"), savedPeople(basedir))
 		if err != nil {
 			return err
 		}
 
-		result[model.Identifier] = identifier
-		result[model.Name] = name
-		result[model.Description] = description
-		result[model.Version] = version
-		result[model.Maintainer] = maintainer
-		result[model.ProgrammingLanguage] = programmingLanguage
-		result[model.DevelopmentStatus] = developmentStatus
-		result[model.License] = licenseDetailsUrl
-		result[model.RuntimePlatform] = runtimePlatform
-		result[model.CodeRepository] = codeRepository
-		result[model.Readme] = readme
-
-		codemeta := *model.NewCodemeta(&result)
+		if fromGit {
+			authors, err := selectFromGit(reader, writer, workingDir, nil, "Author", input)
+			if err != nil {
+				return err
+			}
+			if len(authors) > 0 {
+				builder.Set(model.Author, authors)
+			}
+		}
 
-		err = utils.Marshal(inProgressFilePath, codemeta)
+		doc, err = builder.
+			Identifier(*identifier).
+			Name(*name).
+			Description(*description).
+			Version(*version).
+			Set(model.Maintainer, maintainer).
+			ProgrammingLanguage(codemeta.NewComputerLanguage(*programmingLanguageName, *programmingLanguageURL)).
+			DevelopmentStatus(developmentStatus).
+			License(licenseDetailsUrl).
+			Set(model.RuntimePlatform, runtimePlatformValue(*runtimePlatformNames)).
+			CodeRepository(*codeRepository).
+			Readme(*readme).
+			Build()
 		if err != nil {
 			handleErr(writer, err)
-			return writer.Errorf("unable to save in-progress codemeta.json file after editing")
+			return writer.Errorf("unable to create the in-progress codemeta.json file")
 		}
 	}
 
+	// prompts answered by pressing Enter leave empty values, which are removed
+	json, err := doc.JSON()
+	if err == nil {
+		json, err = removeEmpty(json)
+	}
+	if err == nil && template != nil {
+		err = utils.MarshalBytesLike(inProgressFilePath, json, template)
+	} else if err == nil {
+		err = utils.MarshalBytes(inProgressFilePath, json)
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	}
+
 	writer.Println(successMsg)
 	writer.Println("👇 You can now add authors, contributors, keywords, and other fields to the in-progress codemeta.json file.")
 	writer.Println("➡️  To add/remove authors, contributors or keywords, run the following commands:")
@@ -179,6 +215,111 @@ func new(basedir string, reader utils.Reader, writer utils.Writer, inFile string
 	return nil
 }
 
+var developmentStatusOptions = []model.MenuOption{
+	{Name: "Abandoned", Type: "abandoned"},
+	{Name: "Active", Type: "active"},
+	{Name: "Concept", Type: "concept"},
+	{Name: "Inactive", Type: "inactive"},
+	{Name: "Moved", Type: "moved"},
+	{Name: "Suspended", Type: "suspended"},
+	{Name: "Unsupported", Type: "unsupported"},
+	{Name: "WIP", Type: "wip"},
+}
+
+// returns the development status given with the --status flag or prompts for a selection
+func developmentStatusPrompt(reader utils.Reader, writer utils.Writer, input *utils.Input) (string, error) {
+	if status, ok := input.Get("status"); ok {
+		for _, option := range developmentStatusOptions {
+			if strings.EqualFold(option.Type, status) {
+				return option.Name, nil
+			}
+		}
+		return "", writer.Errorf("invalid value for --%s: %s, see: https://www.repostatus.org/ for a list of valid values", input.Flag("status"), status)
+	}
+	if input.IsNoInput() {
+		return "", input.Missing("status")
+	}
+
+	templates := &promptui.SelectTemplates{
+		Label:    "{{ . }}",
+		Active:   "➞ {{ .Name | cyan }}",
+		Inactive: "  {{ .Name | cyan }}",
+		Selected: `{{ "Select a development status (see: https://www.repostatus.org/):" | faint}} {{ .Name | faint }}`,
+		Details: `--------- Status ----------
+{{ "Name:" | faint }}	{{ .Name }}`,
+	}
+
+	prompt := promptui.Select{
+		Label:     "Select a development status from the list below (see: https://www.repostatus.org/)",
+		Items:     developmentStatusOptions,
+		Templates: templates,
+		Size:      8,
+		Searcher:  nil,
+		Stdin:     reader.Stdin(),
+		Stdout:    writer.Stdout(),
+	}
+
+	i, _, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+	return developmentStatusOptions[i].Name, nil
+}
+
+// returns the comma-separated runtime platforms, a single platform is kept as a string
+func runtimePlatformValue(names string) any {
+	platforms := []any{}
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			platforms = append(platforms, name)
+		}
+	}
+	if len(platforms) == 1 {
+		return platforms[0]
+	}
+	if len(platforms) == 0 {
+		return names
+	}
+	return platforms
+}
+
+// returns the continuous integration URLs given with the --continuous-integration flag
+// as a comma-separated list, or the inferred URLs confirmed by the user
+func continuousIntegrationPrompt(reader utils.Reader, writer utils.Writer, input *utils.Input, inferred []string) ([]any, error) {
+	stdin := reader.Stdin()
+	stdout := writer.Stdout()
+
+	urls := []any{}
+	if value, ok := input.Get("continuous-integration"); ok {
+		for _, url := range strings.Split(value, ",") {
+			url = strings.TrimSpace(url)
+			if url == "" {
+				continue
+			}
+			err := utils.ValidUrl(url)
+			if err != nil {
+				return nil, writer.Errorf("invalid value for --%s: %s", input.Flag("continuous-integration"), err.Error())
+			}
+			urls = append(urls, url)
+		}
+		return urls, nil
+	}
+	for _, url := range inferred {
+		ok := true
+		if !input.IsNoInput() {
+			var err error
+			ok, err = utils.MkConfirm(&stdin, &stdout, "Add the continuous integration URL "+url)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if ok {
+			urls = append(urls, url)
+		}
+	}
+	return urls, nil
+}
+
 var inputFile string
 
 // newCmd represents the new command
@@ -195,16 +336,37 @@ fields to the in-progress codemeta.json, then run:
 
 codemetagenerator generate
 
-to generate the resultant 'codemeta.json' file, optionally selecting the file destination.`,
+to generate the resultant 'codemeta.json' file, optionally selecting the file destination.
+
+When run inside a git repository, the code repository is inferred from the git remote,
+the issue tracker from known forges (GitHub, GitLab, Bitbucket and Codeberg) and the
+continuous integration URLs from the GitHub Actions, GitLab CI and CircleCI configurations
+found in the repository. You are asked to confirm each inferred value.
+
+Pass [--from-git] to select the authors from the commit authors of the git repository
+in the working directory, see "add contributor --help" for details.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return new(utils.UserHomeDir, &utils.StdinReader{}, &utils.StdoutWriter{}, inputFile)
+		return new(utils.UserHomeDir, &utils.StdinReader{}, &utils.StdoutWriter{}, inputFile, ".", fromGit, inputFromFlags(cmd))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(newCmd)
 
-	utils.MkHomeDir(utils.UserHomeDir)
-
 	newCmd.Flags().StringVarP(&inputFile, "input", "i", "", "path to an input 'codemeta.json' file. If not specified, a new file will be started.")
+	newCmd.Flags().String("identifier", "", "unique identifier of the project")
+	newCmd.Flags().String("name", "", "name of the project")
+	newCmd.Flags().String("description", "", "description of the project")
+	newCmd.Flags().String("status", "", "development status of the project (see: https://www.repostatus.org/)")
+	newCmd.Flags().String("code-repository", "", "URL of the code repository of the project")
+	newCmd.Flags().String("issue-tracker", "", "URL of the issue tracker of the project")
+	newCmd.Flags().String("continuous-integration", "", "comma-separated URLs of the continuous integration of the project")
+	newCmd.Flags().String("language-name", "", "name of the programming language of the project")
+	newCmd.Flags().String("language-url", "", "URL of the programming language of the project")
+	newCmd.Flags().String("runtime-platform", "", "comma-separated names of the runtime platforms of the project")
+	newCmd.Flags().String("version", "", "version of the project")
+	newCmd.Flags().String("license", "", "SPDX license ID of the project (see: https://spdx.org/licenses/)")
+	newCmd.Flags().String("readme", "", "URL of the README file of the project")
+	addPersonOrOrganizationFlags(newCmd, "maintainer-", "maintainer")
+	addFromGitFlags(newCmd, "authors")
 }
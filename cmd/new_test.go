@@ -3,8 +3,10 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/cacoco/codemetagenerator/internal/config"
 	"github.com/cacoco/codemetagenerator/internal/model"
 	"github.com/cacoco/codemetagenerator/internal/utils"
 	"github.com/ohler55/ojg/oj"
@@ -61,7 +63,7 @@ func Test_ExecuteNewCmd(t *testing.T) {
 	writer := utils.TestWriter{}
 
 	newCmd := &cobra.Command{Use: "new", RunE: func(cmd *cobra.Command, args []string) error {
-		return new(temp, &reader, &writer, "")
+		return new(temp, &reader, &writer, "", "", false, &utils.Input{})
 	},
 	}
 	buf := bytes.NewBufferString("")
@@ -128,7 +130,7 @@ func Test_ExecuteNewCmdWithInputFlag(t *testing.T) {
 	writer := utils.TestWriter{}
 
 	newCmd := &cobra.Command{Use: "new", RunE: func(cmd *cobra.Command, args []string) error {
-		return new(temp, &reader, &writer, "../testdata/testmeta.json")
+		return new(temp, &reader, &writer, "../testdata/testmeta.json", "", false, &utils.Input{})
 	},
 	}
 	buf := bytes.NewBufferString("")
@@ -158,4 +160,318 @@ func Test_ExecuteNewCmdWithInputFlag(t *testing.T) {
 	}
 
 	g.Ω(m).Should(gomega.Equal(expected))
+	// the keys keep the order of the input file
+	g.Ω(string(fileBytes)).Should(gomega.Equal(`{
+  "@context": "https://w3id.org/codemeta/3.0",
+  "@type": "SoftwareSourceCode",
+  "identifier": "testmeta",
+  "description": "A test codemeta.json file.",
+  "name": "TestMeta"
+}`))
+}
+
+func Test_ExecuteNewCmdWithFlags(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	file, err := os.ReadFile("../testdata/spdx-licenses.json")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = utils.WriteFile(utils.GetLicensesFilePath(temp), file)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	supportedLicenses, err := utils.GetSupportedLicenses(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	SupportedLicenses.setSupportedLicenses(*supportedLicenses)
+	defer reset()
+
+	// only the maintainer organization URL is prompted for
+	var stack utils.Stack[string]
+	stack.Push("https://org.url\n")
+	reader := utils.TestReader{In: utils.TestStdin{Data: stack}}
+	writer := utils.TestWriter{}
+
+	input := &utils.Input{Values: map[string]string{
+		"identifier":          "identifier",
+		"name":                "name",
+		"description":         "description",
+		"status":              "active",
+		"code-repository":     "https://codeRepository.org",
+		"language-name":       "Go",
+		"language-url":        "https://go.dev",
+		"runtime-platform":    "go1.21",
+		"version":             "1.0.0",
+		"license":             "Apache-2.0",
+		"readme":              "https://readme.com",
+		"maintainer-org-name": "Org",
+		"maintainer-org-id":   "",
+	}}
+
+	err = new(temp, &reader, &writer, "", "", false, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.DevelopmentStatus]).Should(gomega.Equal("Active"))
+	g.Ω((*m)[model.License]).Should(gomega.Equal("https://spdx.org/licenses/Apache-2.0.html"))
+	// the empty identifier is removed
+	g.Ω((*m)[model.Maintainer]).Should(gomega.Equal(map[string]any{
+		model.Type: model.OrganizationType,
+		model.Name: "Org",
+		model.URL:  "https://org.url",
+	}))
+
+	// unless the empty values are kept
+	KeepEmpty = true
+	defer func() { KeepEmpty = false }()
+	stack.Push("https://org.url\n")
+	reader = utils.TestReader{In: utils.TestStdin{Data: stack}}
+	err = new(temp, &reader, &writer, "", "", false, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err = utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Maintainer]).Should(gomega.HaveKeyWithValue(model.Id, ""))
+}
+
+func TestNewNoInput(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	input := &utils.Input{Values: map[string]string{"identifier": "identifier"}, NoInput: true}
+	err := new(temp, &reader, &writer, "", "", false, input)
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Ω(err.Error()).Should(gomega.ContainSubstring("--name"))
+}
+
+func TestNewInvalidStatus(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	_, err := developmentStatusPrompt(&reader, &writer, &utils.Input{Values: map[string]string{"status": "finished"}})
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestNewFromGit(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	file, err := os.ReadFile("../testdata/spdx-licenses.json")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = utils.WriteFile(utils.GetLicensesFilePath(temp), file)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	supportedLicenses, err := utils.GetSupportedLicenses(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	SupportedLicenses.setSupportedLicenses(*supportedLicenses)
+	defer reset()
+	repo := gitRepoWithAuthors(t, "Jane Doe <jane@example.com>", "John Smith <john@example.com>", "John Smith <john@example.com>")
+
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	input := &utils.Input{Values: map[string]string{
+		"identifier":          "identifier",
+		"name":                "name",
+		"description":         "description",
+		"status":              "active",
+		"code-repository":     "https://codeRepository.org",
+		"language-name":       "Go",
+		"language-url":        "https://go.dev",
+		"runtime-platform":    "go1.21",
+		"version":             "1.0.0",
+		"license":             "Apache-2.0",
+		"readme":              "https://readme.com",
+		"maintainer-org-name": "Org",
+		"maintainer-url":      "https://org.url",
+		"maintainer-org-id":   "",
+	}, NoInput: true}
+
+	err = new(temp, &reader, &writer, "", repo, true, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	authors := (*m)[model.Author].([]any)
+	g.Ω(authors).Should(gomega.HaveLen(2))
+	g.Ω(authors[0].(map[string]any)[model.GivenName]).Should(gomega.Equal("John"))
+	g.Ω(authors[0].(map[string]any)[model.FamilyName]).Should(gomega.Equal("Smith"))
+	g.Ω(authors[1].(map[string]any)[model.Email]).Should(gomega.Equal("jane@example.com"))
+}
+
+func TestNewInferred(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	file, err := os.ReadFile("../testdata/spdx-licenses.json")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = utils.WriteFile(utils.GetLicensesFilePath(temp), file)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	supportedLicenses, err := utils.GetSupportedLicenses(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	SupportedLicenses.setSupportedLicenses(*supportedLicenses)
+	defer reset()
+	repo := t.TempDir()
+	os.MkdirAll(filepath.Join(repo, ".git"), 0755)
+	os.WriteFile(filepath.Join(repo, ".git", "config"), []byte("[remote \"origin\"]\n\turl = git@github.com:org/project.git\n"), 0644)
+	os.MkdirAll(filepath.Join(repo, ".github", "workflows"), 0755)
+	os.WriteFile(filepath.Join(repo, ".github", "workflows", "ci.yml"), []byte{}, 0644)
+
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	input := &utils.Input{Values: map[string]string{
+		"identifier":          "identifier",
+		"name":                "name",
+		"description":         "description",
+		"status":              "active",
+		"language-name":       "Go",
+		"language-url":        "https://go.dev",
+		"runtime-platform":    "go1.21",
+		"version":             "1.0.0",
+		"license":             "Apache-2.0",
+		"readme":              "https://readme.com",
+		"maintainer-org-name": "Org",
+		"maintainer-url":      "https://org.url",
+		"maintainer-org-id":   "",
+	}, NoInput: true}
+
+	err = new(temp, &reader, &writer, "", repo, false, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.CodeRepository]).Should(gomega.Equal("https://github.com/org/project"))
+	g.Ω((*m)[model.IssueTracker]).Should(gomega.Equal("https://github.com/org/project/issues"))
+	g.Ω((*m)[model.ContinuousIntegration]).Should(gomega.Equal([]any{"https://github.com/org/project/actions/workflows/ci.yml"}))
+}
+
+func TestContinuousIntegrationPrompt(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var stack utils.Stack[string]
+	stack.Push("\n")  // confirm the second URL
+	stack.Push("n\n") // reject the first URL
+	reader := utils.TestReader{In: utils.TestStdin{Data: stack}}
+	writer := utils.TestWriter{}
+
+	urls, err := continuousIntegrationPrompt(&reader, &writer, &utils.Input{}, []string{"https://ci.org/a", "https://ci.org/b"})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(urls).Should(gomega.Equal([]any{"https://ci.org/b"}))
+
+	urls, err = continuousIntegrationPrompt(&reader, &writer, &utils.Input{Values: map[string]string{"continuous-integration": "https://ci.org/c, https://ci.org/d"}}, []string{"https://ci.org/a"})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(urls).Should(gomega.Equal([]any{"https://ci.org/c", "https://ci.org/d"}))
+
+	_, err = continuousIntegrationPrompt(&reader, &writer, &utils.Input{Values: map[string]string{"continuous-integration": "nope"}}, nil)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestNewConfiguredLicense(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	file, err := os.ReadFile("../testdata/spdx-licenses.json")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = utils.WriteFile(utils.GetLicensesFilePath(temp), file)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	supportedLicenses, err := utils.GetSupportedLicenses(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	SupportedLicenses.setSupportedLicenses(*supportedLicenses)
+	defer reset()
+
+	// the configured license is used when no license is given
+	Config = config.New()
+	err = Config.LoadFlags([]string{"license=MIT"})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	defer func() { Config = nil }()
+
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	input := &utils.Input{Values: map[string]string{
+		"identifier":          "identifier",
+		"name":                "name",
+		"description":         "description",
+		"status":              "active",
+		"code-repository":     "https://codeRepository.org",
+		"language-name":       "Go",
+		"language-url":        "https://go.dev",
+		"runtime-platform":    "go1.21",
+		"version":             "1.0.0",
+		"readme":              "https://readme.com",
+		"maintainer-org-name": "Org",
+		"maintainer-url":      "https://org.url",
+		"maintainer-org-id":   "",
+	}, NoInput: true}
+
+	err = new(temp, &reader, &writer, "", "", false, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.License]).Should(gomega.Equal("https://spdx.org/licenses/MIT.html"))
 }
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/cacoco/codemetagenerator/pkg/codemeta"
+	"github.com/spf13/cobra"
+)
+
+func partyProperty(name string) model.PartyProperty {
+	property, _ := model.LookupPartyProperty(name)
+	return property
+}
+
+// returns the label of the property with the first letter capitalized, e.g., "Copyright holder"
+func capitalizedLabel(property model.PartyProperty) string {
+	label := property.Label()
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// returns the label of the property with its indefinite article, e.g., "an author"
+func articleLabel(property model.PartyProperty) string {
+	label := property.Label()
+	if strings.ContainsRune("aeiou", rune(label[0])) {
+		return "an " + label
+	}
+	return "a " + label
+}
+
+// sets the party as the value of a single valued property or appends it to the values of
+// a multiple valued property
+func putParty(doc *codemeta.SoftwareSourceCode, property model.PartyProperty, party map[string]any) error {
+	if property.Multiple {
+		value, _ := doc.Value(property.Name)
+		return doc.Set(property.Name, appendAll(value, []any{party}))
+	}
+	return doc.Set(property.Name, party)
+}
+
+// prompts for a person or organization, as accepted by the property, and adds it to the
+// in-progress codemeta.json file
+func addParty(reader utils.Reader, writer utils.Writer, basedir string, property model.PartyProperty, input *utils.Input) (*map[string]any, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+	party, err := utils.NewPartyPrompt(&reader, &writer, capitalizedLabel(property), input, savedPeople(basedir), property.Person, property.Organization)
+	if err != nil {
+		return nil, err
+	}
+	err = putParty(doc, property, *party)
+	if err == nil {
+		err = saveInProgressDocument(inProgressFilePath, doc)
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	} else {
+		writer.Println("⭐ Successfully updated in-progress codemeta.json file.")
+	}
+	return party, nil
+}
+
+func author(reader utils.Reader, writer utils.Writer, basedir string, input *utils.Input) (*map[string]any, error) {
+	return addParty(reader, writer, basedir, partyProperty(model.Author), input)
+}
+
+func contributor(reader utils.Reader, writer utils.Writer, basedir string, input *utils.Input) (*map[string]any, error) {
+	return addParty(reader, writer, basedir, partyProperty(model.Contributor), input)
+}
+
+func maintainer(reader utils.Reader, writer utils.Writer, basedir string, input *utils.Input) (*map[string]any, error) {
+	return addParty(reader, writer, basedir, partyProperty(model.Maintainer), input)
+}
+
+// adds the commit authors selected from the git repository containing dir to the property
+func partiesFromGit(reader utils.Reader, writer utils.Writer, basedir string, property model.PartyProperty, dir string, input *utils.Input) ([]any, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+	existing, _ := doc.Value(property.Name)
+	parties, err := selectFromGit(reader, writer, dir, existing, capitalizedLabel(property), input)
+	if err != nil {
+		return nil, err
+	}
+	if len(parties) == 0 {
+		writer.Println(fmt.Sprintf("No new %ss were selected.", property.Label()))
+		return parties, nil
+	}
+	err = doc.Set(property.Name, appendAll(existing, parties))
+	if err == nil {
+		err = saveInProgressDocument(inProgressFilePath, doc)
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	}
+	writer.Println(fmt.Sprintf("⭐ Successfully added %d %s(s) to the in-progress codemeta.json file.", len(parties), property.Label()))
+	return parties, nil
+}
+
+// commit authors are only offered as authors and contributors
+func fromGitAllowed(property model.PartyProperty) bool {
+	return property.Name == model.Author || property.Name == model.Contributor
+}
+
+// returns the sub-command of "add" for the property
+func newPartyCmd(property model.PartyProperty) *cobra.Command {
+	var long strings.Builder
+	fmt.Fprintf(&long, `
+Add %s to the in-progress codemeta.json file.
+
+The %s must be %s. Prompts for the information needed to add the %s and
+then adds it to the in-progress codemeta.json file. `, articleLabel(property), property.Label(), property.Types(), property.Label())
+	if property.Multiple {
+		fmt.Fprintf(&long, `You can add multiple %ss by running
+this command multiple times. `, property.Label())
+	} else {
+		fmt.Fprintf(&long, `Any existing %s is replaced as only
+a single value is allowed. `, property.Label())
+	}
+	fmt.Fprintf(&long, `If you need to remove the %s, run the "delete" command. Run
+the "set" command to edit its properties.
+`, property.Label())
+	if property.Person {
+		long.WriteString(`
+Pass [--me] to add your saved profile, see "codemetagenerator profile set".
+`)
+	}
+	if fromGitAllowed(property) {
+		fmt.Fprintf(&long, `
+Pass [--from-git] to instead select any number of %ss from the commit authors of the
+git repository in the working directory. Authors are read through the .mailmap file,
+aggregated by email and ranked by their number of commits or their most recent commit
+[--sort commits|recent], optionally limited to a date range [--since, --until]. Authors
+whose email is already present are not offered.
+`, property.Label())
+	}
+	long.WriteString(`
+When complete, run "generate" to generate the resultant 'codemeta.json' file.`)
+
+	cmd := &cobra.Command{
+		Use:   property.Name,
+		Args:  cobra.NoArgs,
+		Short: fmt.Sprintf("Adds %s to the in-progress codemeta.json file", articleLabel(property)),
+		Long:  long.String(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if me {
+				_, err := addMe(&utils.StdoutWriter{}, utils.UserHomeDir, property)
+				return err
+			}
+			if fromGit && fromGitAllowed(property) {
+				_, err := partiesFromGit(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, property, ".", inputFromFlags(cmd))
+				return err
+			}
+			_, err := addParty(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, property, inputFromFlags(cmd))
+			return err
+		},
+	}
+	if flag := property.Flag(); flag != property.Name {
+		cmd.Aliases = []string{flag}
+	}
+
+	switch {
+	case property.Person && property.Organization:
+		addPersonOrOrganizationFlags(cmd, "", property.Label())
+	case property.Person:
+		addPersonFlags(cmd, "", property.Label())
+	default:
+		addOrganizationFlags(cmd, "", property.Label())
+	}
+	if property.Person {
+		addMeFlag(cmd, property.Label())
+	}
+	if fromGitAllowed(property) {
+		addFromGitFlags(cmd, property.Label()+"s")
+	}
+	return cmd
+}
+
+func init() {
+	for _, property := range model.PartyProperties {
+		addCmd.AddCommand(newPartyCmd(property))
+	}
+}
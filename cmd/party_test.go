@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestAddParty(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{model.Name: "name"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	organization := &utils.Input{Values: map[string]string{"org-name": "Funder", "url": "https://funder.org", "org-id": "https://ror.org/05dxps055"}}
+	person := &utils.Input{Values: map[string]string{"given-name": "Jane", "family-name": "Doe", "email": "jane@example.com", "orcid": ""}}
+
+	_, err = addParty(&reader, &writer, temp, partyProperty("funder"), organization)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	_, err = addParty(&reader, &writer, temp, partyProperty("editor"), person)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// editor is Person-only
+	_, err = addParty(&reader, &writer, temp, partyProperty("editor"), organization)
+	g.Expect(err).ToNot(gomega.BeNil())
+	// sourceOrganization is Organization-only
+	_, err = addParty(&reader, &writer, temp, partyProperty("sourceOrganization"), person)
+	g.Expect(err).ToNot(gomega.BeNil())
+	// sdPublisher is single valued and replaced
+	_, err = addParty(&reader, &writer, temp, partyProperty("sdPublisher"), person)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	_, err = addParty(&reader, &writer, temp, partyProperty("sdPublisher"), organization)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)["funder"]).Should(gomega.HaveLen(1))
+	g.Ω((*m)["editor"]).Should(gomega.HaveLen(1))
+	g.Ω((*m)["sdPublisher"].(map[string]any)[model.Name]).Should(gomega.Equal("Funder"))
+
+	bytes, _ := utils.LoadFile(inProgressFilePath)
+	g.Expect(cue.Validate(bytes)).To(gomega.BeNil())
+}
+
+func TestAddArgs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(checkArgs(addCmd, []string{"funder"})).To(gomega.BeNil())
+	g.Expect(checkArgs(addCmd, []string{"copyright-holder"})).To(gomega.BeNil())
+	g.Expect(checkArgs(addCmd, []string{"funder", "badarg"})).ToNot(gomega.BeNil())
+	g.Expect(checkArgs(addCmd, []string{"unrecognized"})).ToNot(gomega.BeNil())
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/cacoco/codemetagenerator/internal/diff"
+	"github.com/cacoco/codemetagenerator/internal/patch"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/ohler55/ojg/oj"
+	"github.com/spf13/cobra"
+)
+
+func applyPatch(reader utils.Reader, writer utils.Writer, basedir string, patchFile string, dryRun bool) ([]diff.Change, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	bytes, err := utils.LoadFile(inProgressFilePath)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to load the in-progress codemeta.json file for editing. Have you run \"codemetagenerator new\" yet?")
+	}
+	var doc any
+	err = oj.Unmarshal(bytes, &doc)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to parse the in-progress codemeta.json file")
+	}
+
+	var patchBytes []byte
+	if patchFile == "-" {
+		patchBytes, err = io.ReadAll(reader.Stdin())
+	} else {
+		patchBytes, err = utils.LoadFile(patchFile)
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to read patch file %s", patchFile)
+	}
+
+	p, err := patch.Parse(patchBytes)
+	if err != nil {
+		return nil, writer.Errorf("invalid patch file %s: %s", patchFile, err.Error())
+	}
+	// all operations are applied in memory first, nothing is written if any fails
+	result, err := p.Apply(doc)
+	if err != nil {
+		return nil, writer.Errorf("unable to apply patch file %s: %s", patchFile, err.Error())
+	}
+	if _, ok := result.(map[string]any); !ok {
+		return nil, writer.Errorf("unable to apply patch file %s: the result must be a JSON object", patchFile)
+	}
+
+	changes := diff.Diff(doc, result)
+	if dryRun {
+		if len(changes) == 0 {
+			writer.Println("No changes.")
+		} else {
+			writer.Println(diff.Format(changes))
+		}
+		return changes, nil
+	}
+
+	resultBytes, err := oj.Marshal(result)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	}
+	err = saveInProgressFile(inProgressFilePath, resultBytes)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing: %s", err.Error())
+	}
+	writer.Println("⭐ Successfully updated in-progress codemeta.json file.")
+	return changes, nil
+}
+
+var dryRun bool
+
+// patchCmd represents the patch command
+var patchCmd = &cobra.Command{
+	Use:   "patch <path/to/patch.json | ->",
+	Args:  cobra.ExactArgs(1),
+	Short: "Apply a JSON Patch or JSON Merge Patch file to the in-progress codemeta.json file",
+	Long: `
+Apply a batch of edits to the in-progress codemeta.json file in a single step.
+
+The patch file is either an RFC 6902 JSON Patch (https://datatracker.ietf.org/doc/html/rfc6902),
+a JSON array of "add", "remove", "replace", "move", "copy" and "test" operations, or an
+RFC 7396 JSON Merge Patch (https://datatracker.ietf.org/doc/html/rfc7396), a JSON object
+which is merged into the file. Pass "-" to read the patch from stdin.
+
+The patch is applied atomically: if any operation fails, including a "test" operation,
+the in-progress file is left unchanged. Pass [--dry-run] to print the resulting changes
+without writing them.
+
+Examples:
+
+codemetagenerator patch changes.json
+echo '[{"op": "replace", "path": "/version", "value": "1.2.0"}]' | codemetagenerator patch -
+echo '{"version": "1.2.0", "releaseNotes": null}' | codemetagenerator patch --dry-run -
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := applyPatch(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, args[0], dryRun)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(patchCmd)
+
+	patchCmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the changes the patch would make without writing them")
+}
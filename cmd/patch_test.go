@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/diff"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func setupPatchTest(t *testing.T) string {
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+
+	testMap := map[string]any{
+		model.Context:  model.DefaultContext,
+		model.Type:     model.SoftwareSourceCodeType,
+		model.Name:     "name",
+		model.Version:  "1.0.0",
+		model.Keywords: []any{"go"},
+	}
+	err := utils.Marshal(utils.GetInProgressFilePath(temp), testMap)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	return temp
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := setupPatchTest(t)
+	patchFile := temp + "/patch.json"
+	utils.WriteFile(patchFile, []byte(`[
+		{"op": "test", "path": "/version", "value": "1.0.0"},
+		{"op": "replace", "path": "/version", "value": "1.1.0"},
+		{"op": "add", "path": "/keywords/-", "value": "cli"}
+	]`))
+
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+	changes, err := applyPatch(&reader, &writer, temp, patchFile, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(changes).Should(gomega.HaveLen(2))
+
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Version]).Should(gomega.Equal("1.1.0"))
+	g.Ω((*m)[model.Keywords]).Should(gomega.Equal([]any{"go", "cli"}))
+}
+
+func TestApplyJSONPatchFailedTest(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := setupPatchTest(t)
+	patchFile := temp + "/patch.json"
+	utils.WriteFile(patchFile, []byte(`[
+		{"op": "replace", "path": "/name", "value": "other"},
+		{"op": "test", "path": "/version", "value": "2.0.0"}
+	]`))
+
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+	_, err := applyPatch(&reader, &writer, temp, patchFile, false)
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	// nothing was written
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Name]).Should(gomega.Equal("name"))
+}
+
+func TestApplyMergePatchDryRun(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := setupPatchTest(t)
+
+	var stack utils.Stack[string]
+	stack.Push(`{"version": null, "description": "description"}`)
+	reader := utils.TestReader{In: utils.TestStdin{Data: stack}}
+	writer := utils.TestWriter{}
+	changes, err := applyPatch(&reader, &writer, temp, "-", true)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(changes).Should(gomega.Equal([]diff.Change{
+		{Kind: diff.Added, Path: model.Description, New: "description"},
+		{Kind: diff.Removed, Path: model.Version, Old: "1.0.0"},
+	}))
+
+	// nothing was written
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Version]).Should(gomega.Equal("1.0.0"))
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/people"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func loadPeople(writer utils.Writer, basedir string) ([]map[string]any, error) {
+	entries, err := people.Load(basedir)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to load the address book")
+	}
+	return entries, nil
+}
+
+func savePeople(writer utils.Writer, basedir string, entries []map[string]any) error {
+	err := people.Save(basedir, entries)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to save the address book")
+	}
+	return nil
+}
+
+func peopleAdd(reader utils.Reader, writer utils.Writer, basedir string, input *utils.Input) (*map[string]any, error) {
+	entries, err := loadPeople(writer, basedir)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := utils.NewPersonOrOrganizationPrompt(&reader, &writer, "Person or organization", input, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = people.Valid(*entry)
+	if err != nil {
+		return nil, writer.Errorf("unable to add to the address book: %s", err.Error())
+	}
+	entries, replaced := people.Upsert(entries, *entry)
+	err = savePeople(writer, basedir, entries)
+	if err != nil {
+		return nil, err
+	}
+	if replaced {
+		writer.Println(fmt.Sprintf("⭐ Successfully updated %s in the address book.", model.PartyLabel(*entry)))
+	} else {
+		writer.Println(fmt.Sprintf("⭐ Successfully added %s to the address book.", model.PartyLabel(*entry)))
+	}
+	return entry, nil
+}
+
+func peopleList(writer utils.Writer, basedir string) ([]map[string]any, error) {
+	entries, err := loadPeople(writer, basedir)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		writer.Println("The address book is empty, run \"codemetagenerator people add\" to add people.")
+		return entries, nil
+	}
+	for _, entry := range entries {
+		writer.Println(fmt.Sprintf("%s\t%s", entry[model.Type], model.PartyLabel(entry)))
+	}
+	return entries, nil
+}
+
+// adds the persons and organizations of the file to the address book, replacing the entries
+// with the same "@id" or email. Entries without an "@id" or email are skipped.
+func peopleImport(writer utils.Writer, basedir string, file string) ([]map[string]any, error) {
+	entries, err := loadPeople(writer, basedir)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := utils.LoadFile(file)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to read file %s", file)
+	}
+	imported, err := people.Parse(bytes)
+	if err != nil {
+		return nil, writer.Errorf("unable to import %s: %s", file, err.Error())
+	}
+
+	added, updated, skipped := 0, 0, 0
+	for _, entry := range imported {
+		if err := people.Valid(entry); err != nil {
+			writer.Println(fmt.Sprintf("Skipping: %s", err.Error()))
+			skipped++
+			continue
+		}
+		var replaced bool
+		entries, replaced = people.Upsert(entries, entry)
+		if replaced {
+			updated++
+		} else {
+			added++
+		}
+	}
+	err = savePeople(writer, basedir, entries)
+	if err != nil {
+		return nil, err
+	}
+	writer.Println(fmt.Sprintf("⭐ Successfully imported '%s': %d added, %d updated, %d skipped.", filepath.Base(file), added, updated, skipped))
+	return entries, nil
+}
+
+func peopleExport(writer utils.Writer, basedir string, outFile string) error {
+	entries, err := loadPeople(writer, basedir)
+	if err != nil {
+		return err
+	}
+	json := utils.FormatJSON(entries)
+	if outFile == "" {
+		writer.Println(json)
+		return nil
+	}
+	err = utils.WriteJSON(outFile, json)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to write the address book to %s", outFile)
+	}
+	writer.Println(fmt.Sprintf("⭐ Successfully exported the address book to '%s'.", outFile))
+	return nil
+}
+
+var peopleOutput string
+
+// peopleCmd represents the people command
+var peopleCmd = &cobra.Command{
+	Use:   "people",
+	Args:  cobra.NoArgs,
+	Short: "Manage an address book of people and organizations [add, list, import, export]",
+	Long: `
+Manage a local address book of people and organizations which are reused across
+projects. Entries are keyed by their "@id" or email, adding an entry with the same
+"@id" or email replaces the existing entry.
+
+The address book is stored in the $HOME/.codemetagenerator directory. Its entries are
+offered by the interactive prompts of, e.g., "add author" and can be searched by typing
+any part of their name or email.`,
+}
+
+// peopleAddCmd represents the people add command
+var peopleAddCmd = &cobra.Command{
+	Use:   "add",
+	Args:  cobra.NoArgs,
+	Short: "Add a person or organization to the address book",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := peopleAdd(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, inputFromFlags(cmd))
+		return err
+	},
+}
+
+// peopleListCmd represents the people list command
+var peopleListCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.NoArgs,
+	Short: "List the people and organizations in the address book",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := peopleList(&utils.StdoutWriter{}, utils.UserHomeDir)
+		return err
+	},
+}
+
+// peopleImportCmd represents the people import command
+var peopleImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Import people and organizations into the address book",
+	Long: `
+Import people and organizations into the address book from a JSON file with an array of
+entries, e.g., one written by "people export", or from the authors, contributors,
+maintainers and other people and organizations of a codemeta.json file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := peopleImport(&utils.StdoutWriter{}, utils.UserHomeDir, args[0])
+		return err
+	},
+}
+
+// peopleExportCmd represents the people export command
+var peopleExportCmd = &cobra.Command{
+	Use:   "export",
+	Args:  cobra.NoArgs,
+	Short: "Export the address book as JSON to the optional output file or to the console",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return peopleExport(&utils.StdoutWriter{}, utils.UserHomeDir, peopleOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(peopleCmd)
+	peopleCmd.AddCommand(peopleAddCmd)
+	peopleCmd.AddCommand(peopleListCmd)
+	peopleCmd.AddCommand(peopleImportCmd)
+	peopleCmd.AddCommand(peopleExportCmd)
+
+	addPersonOrOrganizationFlags(peopleAddCmd, "", "address book")
+	peopleExportCmd.Flags().StringVarP(&peopleOutput, "output", "o", "", "path to the output file. If not specified, the output is printed to the console.")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestPeopleAddListAndExport(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	input := &utils.Input{Values: map[string]string{"org-name": "Org", "url": "https://org.example", "org-id": "https://ror.org/05dxps055"}}
+	_, err := peopleAdd(&reader, &writer, temp, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	input = &utils.Input{Values: map[string]string{"given-name": "Jane", "family-name": "Doe", "email": "jane@example.com", "orcid": ""}}
+	_, err = peopleAdd(&reader, &writer, temp, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// the same email replaces the entry
+	input = &utils.Input{Values: map[string]string{"given-name": "Jane Q.", "family-name": "Doe", "email": "jane@example.com", "orcid": ""}}
+	_, err = peopleAdd(&reader, &writer, temp, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// neither an @id nor an email
+	input = &utils.Input{Values: map[string]string{"given-name": "Anon", "family-name": "", "email": "", "orcid": ""}}
+	_, err = peopleAdd(&reader, &writer, temp, input)
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	entries, err := peopleList(&writer, temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(entries).Should(gomega.HaveLen(2))
+	g.Ω(entries[1][model.GivenName]).Should(gomega.Equal("Jane Q."))
+
+	outFile := temp + "/people.json"
+	err = peopleExport(&writer, temp, outFile)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// importing the export into another address book
+	other := t.TempDir()
+	os.Mkdir(utils.GetHomeDir(other), 0755)
+	imported, err := peopleImport(&writer, other, outFile)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(imported).Should(gomega.Equal(entries))
+}
+
+func TestPeopleImportCodemeta(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	writer := utils.TestWriter{}
+
+	codemetaFile := temp + "/codemeta.json"
+	err := utils.Marshal(codemetaFile, *model.NewCodemeta(&map[string]any{
+		model.Author: []any{
+			map[string]any{model.Type: model.PersonType, model.GivenName: "Jane", model.Email: "jane@example.com"},
+			map[string]any{model.Type: model.PersonType, model.GivenName: "Anon"},
+		},
+		model.Maintainer: map[string]any{model.Type: model.OrganizationType, model.Name: "Org", model.Id: "https://ror.org/05dxps055"},
+	}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	entries, err := peopleImport(&writer, temp, codemetaFile)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(entries).Should(gomega.HaveLen(2))
+
+	// the address book is offered by the prompts
+	saved := savedPeople(temp)
+	g.Ω(saved).Should(gomega.HaveLen(2))
+
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err = utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	var stack utils.Stack[string]
+	stack.Push("ror.org\n")
+	reader := utils.TestReader{In: utils.TestStdin{Data: stack}}
+	author, err := author(&reader, &writer, temp, &utils.Input{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*author)[model.Name]).Should(gomega.Equal("Org"))
+}
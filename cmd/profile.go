@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/merge"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/people"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// returns the saved people offered by the person prompts: the "me" profile followed by
+// the entries of the address book
+func savedPeople(basedir string) []map[string]any {
+	saved := []map[string]any{}
+	profile, err := utils.LoadProfile(basedir)
+	if err == nil && profile != nil {
+		saved = append(saved, *profile)
+	}
+	entries, err := people.Load(basedir)
+	if err != nil {
+		return saved
+	}
+	for _, entry := range entries {
+		if profile == nil || !merge.SameParty(*profile, entry) {
+			saved = append(saved, entry)
+		}
+	}
+	return saved
+}
+
+func profileSet(reader utils.Reader, writer utils.Writer, basedir string, input *utils.Input) (*map[string]any, error) {
+	defaults, err := utils.LoadProfile(basedir)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to load the saved profile")
+	}
+	if defaults == nil {
+		// seed a new profile from the git config
+		name, email := utils.GitUser()
+		givenName, familyName := model.SplitName(name)
+		defaults = &map[string]any{
+			model.GivenName:  givenName,
+			model.FamilyName: familyName,
+			model.Email:      email,
+		}
+	}
+
+	person, err := utils.NewPersonPrompt(&reader, &writer, input, *defaults)
+	if err != nil {
+		return nil, err
+	}
+	err = utils.SaveProfile(basedir, *person)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save the profile")
+	}
+	writer.Println("⭐ Successfully saved your profile.")
+	return person, nil
+}
+
+func profileShow(writer utils.Writer, basedir string) (*map[string]any, error) {
+	profile, err := utils.LoadProfile(basedir)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to load the saved profile")
+	}
+	if profile == nil {
+		return nil, writer.Errorf("no profile has been saved, run \"codemetagenerator profile set\" to save one")
+	}
+	writer.Println(utils.FormatJSON(*profile))
+	return profile, nil
+}
+
+// adds the saved "me" profile to the people of the given property
+func addMe(writer utils.Writer, basedir string, property model.PartyProperty) (*map[string]any, error) {
+	profile, err := utils.LoadProfile(basedir)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to load the saved profile")
+	}
+	if profile == nil {
+		return nil, writer.Errorf("no profile has been saved, run \"codemetagenerator profile set\" to save one")
+	}
+	if !property.Accepts(*profile) {
+		return nil, writer.Errorf("the %s must be %s", property.Label(), property.Types())
+	}
+
+	inProgressFilePath := getInProgressFilePath(basedir)
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _ := doc.Value(property.Name)
+	if email, ok := (*profile)[model.Email].(string); ok && existingEmails(existing)[strings.ToLower(email)] {
+		return nil, writer.Errorf("%s is already %s", model.PartyLabel(*profile), articleLabel(property))
+	}
+	err = putParty(doc, property, *profile)
+	if err == nil {
+		err = saveInProgressDocument(inProgressFilePath, doc)
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	}
+	writer.Println(fmt.Sprintf("⭐ Successfully added %s as %s to the in-progress codemeta.json file.", model.PartyLabel(*profile), articleLabel(property)))
+	return profile, nil
+}
+
+var me bool
+
+func addMeFlag(cmd *cobra.Command, label string) {
+	cmd.Flags().BoolVar(&me, "me", false, fmt.Sprintf("add your saved profile as the %s, see \"codemetagenerator profile set\"", label))
+}
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Args:  cobra.NoArgs,
+	Short: "Manage your saved person details [set, show]",
+	Long: `
+Manage the person details saved as your "me" profile.
+
+The profile is stored in the $HOME/.codemetagenerator directory and can be added with
+"add author --me" or "add maintainer --me". It is also offered by the interactive
+person prompts so you do not need to enter your name, email and ORCID again.`,
+}
+
+// profileSetCmd represents the profile set command
+var profileSetCmd = &cobra.Command{
+	Use:   "set",
+	Args:  cobra.NoArgs,
+	Short: "Save your person details as your profile",
+	Long: `
+Prompts for your person details and saves them as your profile. The prompts offer the
+values of the saved profile, or when no profile has been saved, the user.name and
+user.email from your git config.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := profileSet(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, inputFromFlags(cmd))
+		return err
+	},
+}
+
+// profileShowCmd represents the profile show command
+var profileShowCmd = &cobra.Command{
+	Use:   "show",
+	Args:  cobra.NoArgs,
+	Short: "Show your saved profile",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := profileShow(&utils.StdoutWriter{}, utils.UserHomeDir)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileSetCmd)
+	profileCmd.AddCommand(profileShowCmd)
+
+	addPersonFlags(profileSetCmd, "", "profile")
+}
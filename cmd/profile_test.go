@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestProfileSetAndShow(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	writer := utils.TestWriter{}
+
+	_, err := profileShow(&writer, temp)
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	input := &utils.Input{Values: map[string]string{
+		"given-name":  "Jane",
+		"family-name": "Doe",
+		"email":       "jane@example.com",
+		"orcid":       "https://orcid.org/0000-0000-0000-0000",
+	}}
+	_, err = profileSet(&reader, &writer, temp, input)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	profile, err := profileShow(&writer, temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := map[string]any{
+		model.Type:       model.PersonType,
+		model.GivenName:  "Jane",
+		model.FamilyName: "Doe",
+		model.Email:      "jane@example.com",
+		model.Id:         "https://orcid.org/0000-0000-0000-0000",
+	}
+	g.Ω(*profile).Should(gomega.Equal(expected))
+
+	// the saved values are offered as defaults, only the email is changed
+	// accepting a default is a carriage return
+	var stack utils.Stack[string]
+	stack.Push("\r")
+	stack.Push("jane@example.org\n")
+	stack.Push("\r")
+	stack.Push("\r")
+	reader = utils.TestReader{In: utils.TestStdin{Data: stack}}
+	profile, err = profileSet(&reader, &writer, temp, &utils.Input{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected[model.Email] = "jane@example.org"
+	g.Ω(*profile).Should(gomega.Equal(expected))
+}
+
+func TestAddMe(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{
+		model.Maintainer: map[string]any{model.Type: model.OrganizationType, model.Name: "Org"},
+	}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	writer := utils.TestWriter{}
+
+	// no profile
+	_, err = addMe(&writer, temp, partyProperty(model.Author))
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	me := map[string]any{model.Type: model.PersonType, model.GivenName: "Jane", model.Email: "jane@example.com"}
+	err = utils.SaveProfile(temp, me)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	_, err = addMe(&writer, temp, partyProperty(model.Author))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	_, err = addMe(&writer, temp, partyProperty(model.Maintainer))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// already added
+	_, err = addMe(&writer, temp, partyProperty(model.Author))
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Author]).Should(gomega.Equal([]any{me}))
+	g.Ω((*m)[model.Maintainer]).Should(gomega.Equal([]any{
+		map[string]any{model.Type: model.OrganizationType, model.Name: "Org"},
+		me,
+	}))
+}
+
+func TestAddMaintainerSavedPerson(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	me := map[string]any{model.Type: model.PersonType, model.GivenName: "Jane", model.Email: "jane@example.com"}
+	err = utils.SaveProfile(temp, me)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// the prompt starts by searching the saved people
+	var stack utils.Stack[string]
+	stack.Push("jane\n")
+	reader := utils.TestReader{In: utils.TestStdin{Data: stack}}
+	writer := utils.TestWriter{}
+
+	maintainer, err := maintainer(&reader, &writer, temp, &utils.Input{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*maintainer).Should(gomega.Equal(me))
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Maintainer]).Should(gomega.Equal([]any{me}))
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/release"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func releaseVersion(writer utils.Writer, basedir string, spec string, date string, changelogFile string) (*map[string]any, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	current := ""
+	if currentValue, ok := doc.Value(model.Version); ok && currentValue != nil {
+		current = fmt.Sprint(currentValue)
+	}
+	version, err := release.Bump(current, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if date == "" {
+		date = time.Now().Format(release.DateFormat)
+	} else if _, err := time.Parse(release.DateFormat, date); err != nil {
+		return nil, writer.Errorf("invalid date: %s, expected the format YYYY-MM-DD", date)
+	}
+
+	var releaseNotes string
+	if changelogFile != "" {
+		bytes, err := utils.LoadFile(changelogFile)
+		if err != nil {
+			handleErr(writer, err)
+			return nil, writer.Errorf("unable to read changelog file %s", changelogFile)
+		}
+		releaseNotes, err = release.ChangelogSection(string(bytes), version)
+		if err != nil {
+			return nil, writer.Errorf("unable to read the release notes from %s: %s", changelogFile, err.Error())
+		}
+	}
+
+	doc.Version = version
+	doc.DateModified = date
+	doc.DatePublished = date
+	if releaseNotes != "" {
+		doc.ReleaseNotes = releaseNotes
+	}
+
+	err = saveInProgressDocument(inProgressFilePath, doc)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing: %s", err.Error())
+	}
+	writer.Println(fmt.Sprintf("⭐ Successfully updated the in-progress codemeta.json file for the release of version %s.", version))
+	m, err := doc.Map()
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+var releaseDate string
+var releaseChangelog string
+
+// releaseCmd represents the release command
+var releaseCmd = &cobra.Command{
+	Use:   "release <version | major | minor | patch>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Update the version, dates and release notes of the in-progress codemeta.json file for a release",
+	Long: `
+Update the in-progress codemeta.json file for a new release.
+
+Sets the "version" to the given semantic version (https://semver.org) or bumps the
+current version when "major", "minor" or "patch" is given, e.g., "patch" bumps
+1.2.3 to 1.2.4. The "dateModified" and "datePublished" properties are set to today,
+or the date given with [--date YYYY-MM-DD].
+
+Pass [--changelog CHANGELOG.md] to set "releaseNotes" to the section for the new
+version of a changelog in the Keep a Changelog format (https://keepachangelog.com).
+
+Examples:
+
+codemetagenerator release minor
+codemetagenerator release 2.0.0 --changelog CHANGELOG.md
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := releaseVersion(&utils.StdoutWriter{}, utils.UserHomeDir, args[0], releaseDate, releaseChangelog)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+
+	releaseCmd.Flags().StringVar(&releaseDate, "date", "", "release date in the format YYYY-MM-DD. If not specified, today will be used.")
+	releaseCmd.Flags().StringVar(&releaseChangelog, "changelog", "", "path to a Keep a Changelog formatted file to read the release notes from.")
+}
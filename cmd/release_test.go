@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestReleaseVersion(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	err := utils.Marshal(utils.GetInProgressFilePath(temp), *model.NewCodemeta(&map[string]any{model.Name: "name", model.Version: "1.0.0"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	changelog := temp + "/CHANGELOG.md"
+	utils.WriteFile(changelog, []byte("# Changelog\n\n## [1.1.0] - 2024-02-01\n\n- Feature A\n\n## [1.0.0] - 2024-01-01\n\n- Initial release\n"))
+
+	writer := utils.TestWriter{}
+	_, err = releaseVersion(&writer, temp, "minor", "2024-02-01", changelog)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.Version]).Should(gomega.Equal("1.1.0"))
+	g.Ω((*m)[model.DateModified]).Should(gomega.Equal("2024-02-01"))
+	g.Ω((*m)[model.DatePublished]).Should(gomega.Equal("2024-02-01"))
+	g.Ω((*m)[model.ReleaseNotes]).Should(gomega.Equal("- Feature A"))
+
+	// the dates are valid
+	bytes, _ := utils.LoadFile(utils.GetInProgressFilePath(temp))
+	g.Expect(cue.Validate(bytes)).To(gomega.BeNil())
+}
+
+func TestReleaseVersionErrors(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	err := utils.Marshal(utils.GetInProgressFilePath(temp), *model.NewCodemeta(&map[string]any{model.Name: "name"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	writer := utils.TestWriter{}
+
+	// no current version to bump
+	_, err = releaseVersion(&writer, temp, "patch", "", "")
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	// invalid date
+	_, err = releaseVersion(&writer, temp, "1.0.0", "01/02/2024", "")
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	// missing changelog section
+	changelog := temp + "/CHANGELOG.md"
+	utils.WriteFile(changelog, []byte("# Changelog\n"))
+	_, err = releaseVersion(&writer, temp, "1.0.0", "", changelog)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
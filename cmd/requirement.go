@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/requirements"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/cacoco/codemetagenerator/pkg/codemeta"
+	"github.com/spf13/cobra"
+)
+
+var requirementFrom string
+var devAsSuggestions bool
+
+// adds the entries to the list of the key, replacing the entries with the same name.
+// Returns the number of added and replaced entries.
+func putRequirements(writer utils.Writer, doc *codemeta.SoftwareSourceCode, key string, entries []map[string]any) (int, int, error) {
+	existing, _ := doc.Value(key)
+	list := []any{}
+	for _, item := range appendAll(existing, nil) {
+		if _, ok := item.(map[string]any); ok {
+			list = append(list, item)
+			continue
+		}
+		// the text allowed by softwareSuggestions cannot be an item of a list
+		fmt.Fprintf(writer.StdErr(), "⚠️  replacing the %s %v with the list of requirements\n", key, item)
+	}
+	added, replaced := 0, 0
+	for _, entry := range entries {
+		found := false
+		for i, item := range list {
+			if existing, ok := item.(map[string]any); ok && existing[model.Name] == entry[model.Name] {
+				list[i] = entry
+				found = true
+				break
+			}
+		}
+		if found {
+			replaced++
+		} else {
+			list = append(list, entry)
+			added++
+		}
+	}
+	if len(list) > 0 {
+		err := doc.Set(key, list)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return added, replaced, nil
+}
+
+func validRequirementName(name string) error {
+	if name == "" {
+		return fmt.Errorf("a name is required")
+	}
+	return nil
+}
+
+func validRequirementRepository(url string) error {
+	if url == "" {
+		return nil
+	}
+	return utils.ValidUrl(url)
+}
+
+// adds a single requirement, prompting for its name, version and code repository
+func requirement(reader utils.Reader, writer utils.Writer, basedir string, input *utils.Input) (*map[string]any, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	stdin := reader.Stdin()
+	stdout := writer.Stdout()
+	name, err := input.Prompt(&stdin, &stdout, "name", "Enter the name of the required software", validRequirementName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := input.Prompt(&stdin, &stdout, "version", "Enter the required version of the software", utils.Nop)
+	if err != nil {
+		return nil, err
+	}
+	codeRepository, err := input.Prompt(&stdin, &stdout, "code-repository", "Enter the URL of the code repository of the software (optional)", validRequirementRepository)
+	if err != nil {
+		return nil, err
+	}
+	entry := requirements.Requirement{Name: *name, Version: *version, CodeRepository: *codeRepository}.Entry()
+	_, _, err = putRequirements(writer, doc, model.SoftwareRequirements, []map[string]any{entry})
+	if err == nil {
+		err = saveInProgressDocument(inProgressFilePath, doc)
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	} else {
+		writer.Println("⭐ Successfully updated in-progress codemeta.json file.")
+	}
+	return &entry, nil
+}
+
+// adds the direct dependencies of the lockfile to the softwareRequirements. When
+// devAsSuggestions is set the development dependencies are added to the
+// softwareSuggestions instead.
+func requirementsFrom(writer utils.Writer, basedir string, lockfile string, devAsSuggestions bool) ([]requirements.Requirement, error) {
+	inProgressFilePath := getInProgressFilePath(basedir)
+
+	doc, err := loadInProgressDocument(writer, inProgressFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	read, err := requirements.Read(lockfile)
+	if err != nil {
+		return nil, writer.Errorf("unable to read the requirements: %s", err.Error())
+	}
+	required := []map[string]any{}
+	suggested := []map[string]any{}
+	for _, requirement := range read {
+		if requirement.Dev && devAsSuggestions {
+			suggested = append(suggested, requirement.Entry())
+		} else {
+			required = append(required, requirement.Entry())
+		}
+	}
+	added, replaced, err := putRequirements(writer, doc, model.SoftwareRequirements, required)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to add the requirements")
+	}
+	writer.Println(fmt.Sprintf("Read %d requirement(s) from '%s': %d added, %d updated.", len(required), filepath.Base(lockfile), added, replaced))
+	if devAsSuggestions {
+		added, replaced, err = putRequirements(writer, doc, model.SoftwareSuggestions, suggested)
+		if err != nil {
+			handleErr(writer, err)
+			return nil, writer.Errorf("unable to add the suggestions")
+		}
+		writer.Println(fmt.Sprintf("Read %d development requirement(s) as suggestions: %d added, %d updated.", len(suggested), added, replaced))
+	}
+
+	err = saveInProgressDocument(inProgressFilePath, doc)
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to save in-progress codemeta.json file after editing")
+	} else {
+		writer.Println("⭐ Successfully updated in-progress codemeta.json file.")
+	}
+	return read, nil
+}
+
+// requirementCmd represents the requirement command
+var requirementCmd = &cobra.Command{
+	Use:   "requirement",
+	Args:  cobra.NoArgs,
+	Short: "Adds software requirements to the in-progress codemeta.json file",
+	Long: `
+Add a required software to the 'softwareRequirements' of the in-progress codemeta.json
+file. Prompts for the name, version and optional code repository of the software, which
+is added as a SoftwareSourceCode when its code repository is given and as a
+SoftwareApplication otherwise. A requirement with the same name is replaced.
+
+Use --from to add the direct dependencies read from a lockfile instead:
+
+codemetagenerator add requirement --from go.sum
+codemetagenerator add requirement --from package-lock.json --dev-as-suggestions
+
+Supported lockfiles are go.sum (with the direct requirements of the go.mod next to it),
+package-lock.json (lockfileVersion 2 or 3), requirements.txt (any *requirements*.txt
+file) and Cargo.lock. The development dependencies, i.e., the devDependencies of
+package-lock.json, the [dev-dependencies] of the Cargo.toml next to Cargo.lock and
+every requirement of a requirements file named like requirements-dev.txt or
+test-requirements.txt, are added to the 'softwareSuggestions' instead with
+--dev-as-suggestions. A 'softwareSuggestions' text is replaced with the list.
+
+When complete, run "generate" to generate the resultant 'codemeta.json' file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if requirementFrom != "" {
+			_, err := requirementsFrom(&utils.StdoutWriter{}, utils.UserHomeDir, requirementFrom, devAsSuggestions)
+			return err
+		}
+		_, err := requirement(&utils.StdinReader{}, &utils.StdoutWriter{}, utils.UserHomeDir, inputFromFlags(cmd))
+		return err
+	},
+}
+
+func init() {
+	addCmd.AddCommand(requirementCmd)
+
+	requirementCmd.Flags().StringVar(&requirementFrom, "from", "", "path to a lockfile to read the direct dependencies from: go.sum, package-lock.json, requirements.txt or Cargo.lock")
+	requirementCmd.Flags().BoolVar(&devAsSuggestions, "dev-as-suggestions", false, "add the development and test dependencies of the lockfile to the softwareSuggestions")
+	requirementCmd.Flags().String("name", "", "name of the required software")
+	requirementCmd.Flags().String("version", "", "required version of the software")
+	requirementCmd.Flags().String("code-repository", "", "URL of the code repository of the software")
+}
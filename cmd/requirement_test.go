@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestRequirement(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{model.Name: "name"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	reader := utils.TestReader{In: utils.TestStdin{Data: *utils.NilStack}}
+	writer := utils.TestWriter{}
+
+	_, err = requirement(&reader, &writer, temp, &utils.Input{Values: map[string]string{"name": "Go", "version": "1.21", "code-repository": ""}})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// the same name is replaced
+	entry, err := requirement(&reader, &writer, temp, &utils.Input{Values: map[string]string{"name": "Go", "version": "1.22", "code-repository": "https://github.com/golang/go"}})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*entry)[model.Type]).Should(gomega.Equal(model.SoftwareSourceCodeType))
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.SoftwareRequirements]).Should(gomega.HaveLen(1))
+}
+
+func TestRequirementsFrom(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{model.Name: "name"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	writer := utils.TestWriter{}
+
+	// the go.sum of this module
+	read, err := requirementsFrom(&writer, temp, filepath.Join("..", "go.sum"), false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(read).ShouldNot(gomega.BeEmpty())
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "requirements-test.txt"), []byte("pytest==8.0.0\n"), 0644)
+	_, err = requirementsFrom(&writer, temp, filepath.Join(dir, "requirements-test.txt"), true)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// reading again updates the entries
+	_, err = requirementsFrom(&writer, temp, filepath.Join("..", "go.sum"), false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω((*m)[model.SoftwareRequirements]).Should(gomega.HaveLen(len(read)))
+	g.Ω((*m)[model.SoftwareSuggestions]).Should(gomega.HaveLen(1))
+
+	bytes, _ := utils.LoadFile(inProgressFilePath)
+	g.Expect(cue.Validate(bytes)).To(gomega.BeNil())
+}
+
+func TestRequirementsFromReplacesSuggestionsText(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, *model.NewCodemeta(&map[string]any{model.Name: "name", model.SoftwareSuggestions: "a text editor"}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	writer := utils.TestWriter{}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "requirements-test.txt"), []byte("pytest==8.0.0\n"), 0644)
+	_, err = requirementsFrom(&writer, temp, filepath.Join(dir, "requirements-test.txt"), true)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	m, err := utils.Unmarshal(inProgressFilePath)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// the text cannot be an item of the list, it is replaced
+	g.Ω((*m)[model.SoftwareSuggestions]).Should(gomega.HaveLen(1))
+	g.Ω((*m)[model.SoftwareSuggestions].([]any)[0]).Should(gomega.HaveKeyWithValue(model.Name, "pytest"))
+
+	bytes, _ := utils.LoadFile(inProgressFilePath)
+	g.Expect(cue.Validate(bytes)).To(gomega.BeNil())
+}
@@ -4,14 +4,36 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/cacoco/codemetagenerator/internal/config"
+	"github.com/cacoco/codemetagenerator/internal/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/people"
 	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/cacoco/codemetagenerator/pkg/codemeta"
 	"github.com/ohler55/ojg/oj"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var Debug bool
+var Draft string
+var ProjectFile string
+var Local bool
+var NoInput bool
+var ConfigOverrides []string
+var Home string
+var KeepEmpty bool
+
+// the layered configuration, loaded by initConfig
+var Config *config.Config
+var configErr error
+
+// the error resolving the configuration, cache and state directories
+var dirsErr error
 
 func SetVersionInfo(version, commit, date string) {
 	rootCmd.Version = fmt.Sprintf("%s (Built on %s from Git SHA %s)", version, date, commit)
@@ -95,6 +117,138 @@ func (l *Licenses) setSupportedLicenses(supportedLicenses []string) {
 	l.supportedLicenses = supportedLicenses
 }
 
+// returns the project-local codemeta.json file when running in project-local mode,
+// otherwise the in-progress file path of the draft selected with the --draft flag,
+// falling back to the draft last selected with "draft switch"
+func getInProgressFilePath(basedir string) string {
+	if ProjectFile != "" {
+		return ProjectFile
+	}
+	name := Draft
+	if name == "" {
+		name = utils.GetCurrentDraft(basedir)
+	}
+	return utils.GetDraftFilePath(basedir, name)
+}
+
+func isProjectLocal() bool {
+	return ProjectFile != ""
+}
+
+// resolves the project-local codemeta.json file from the --file and --local flags
+func resolveProjectFile(workingDir string) error {
+	if ProjectFile != "" {
+		if Draft != "" {
+			return fmt.Errorf("the --file and --draft flags cannot be used together")
+		}
+		return nil
+	}
+	if Local {
+		if Draft != "" {
+			return fmt.Errorf("the --local and --draft flags cannot be used together")
+		}
+		found, err := utils.FindProjectFile(workingDir)
+		if err != nil {
+			return err
+		}
+		ProjectFile = found
+	}
+	return nil
+}
+
+// removes the empty strings, objects and arrays of the JSON, unless --keep-empty is given
+func removeEmpty(bytes []byte) ([]byte, error) {
+	if KeepEmpty {
+		return bytes, nil
+	}
+	tidied, _, err := utils.Tidy(bytes)
+	return tidied, err
+}
+
+// writes the in-progress file without its empty values, see removeEmpty. In project-local
+// mode the result is validated first so that an invalid codemeta.json file is never
+// written into the project, and it is formatted as the .editorconfig files of the project
+// specify.
+func saveInProgressFile(path string, bytes []byte) error {
+	bytes, err := removeEmpty(bytes)
+	if err != nil {
+		return err
+	}
+	if isProjectLocal() {
+		err := cue.Validate(bytes)
+		if err != nil {
+			return fmt.Errorf("refusing to write invalid codemeta.json file: %v", err)
+		}
+		return utils.MarshalBytesFormatted(path, bytes, utils.FormatOf(path))
+	}
+	return utils.MarshalBytes(path, bytes)
+}
+
+// loads the in-progress file as a CodeMeta document for editing
+func loadInProgressDocument(writer utils.Writer, path string) (*codemeta.SoftwareSourceCode, error) {
+	bytes, err := utils.LoadFile(path)
+	var doc *codemeta.SoftwareSourceCode
+	if err == nil {
+		doc, err = codemeta.Parse(bytes)
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return nil, writer.Errorf("unable to load the in-progress codemeta.json file for editing. Have you run \"codemetagenerator new\" yet?")
+	}
+	return doc, nil
+}
+
+// writes the document to the in-progress file, see saveInProgressFile
+func saveInProgressDocument(path string, doc *codemeta.SoftwareSourceCode) error {
+	bytes, err := doc.JSON()
+	if err != nil {
+		return err
+	}
+	return saveInProgressFile(path, bytes)
+}
+
+// collects the values of the flags given on the command line as input for prompts
+func inputFromFlags(cmd *cobra.Command) *utils.Input {
+	values := make(map[string]string)
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		values[flag.Name] = flag.Value.String()
+	})
+	return &utils.Input{Values: values, NoInput: NoInput}
+}
+
+// adds the flags which supply the values of a person or organization prompt
+func addPersonOrOrganizationFlags(cmd *cobra.Command, prefix string, label string) {
+	addPersonFlags(cmd, prefix, label)
+	addOrganizationFlags(cmd, prefix, label)
+}
+
+// adds the flags which supply the values of an organization prompt
+func addOrganizationFlags(cmd *cobra.Command, prefix string, label string) {
+	cmd.Flags().String(prefix+"org-name", "", "name of the "+label+" organization")
+	cmd.Flags().String(prefix+"url", "", "URL of the "+label+" organization")
+	cmd.Flags().String(prefix+"org-id", "", "identifier of the "+label+" organization")
+}
+
+// appends the values to the value of a property which may be unset, a single value or a list
+func appendAll(value any, values []any) []any {
+	switch v := value.(type) {
+	case nil:
+		return values
+	case []any:
+		return append(v, values...)
+	default:
+		return append([]any{v}, values...)
+	}
+}
+
+// adds the flags which supply the values of a person prompt
+func addPersonFlags(cmd *cobra.Command, prefix string, label string) {
+	cmd.Flags().String(prefix+"given-name", "", "given (first) name of the "+label+" person")
+	cmd.Flags().String(prefix+"family-name", "", "family (last) name of the "+label+" person")
+	cmd.Flags().String(prefix+"email", "", "email address of the "+label+" person")
+	cmd.Flags().String(prefix+"orcid", "", "identifier of the "+label+" person (see: https://orcid.org)")
+}
+
 func handleErr(writer utils.Writer, err error) {
 	if err != nil {
 		if Debug {
@@ -109,8 +263,39 @@ var rootCmd = &cobra.Command{
 	Short: "An interactive codemeta.json file generator for projects",
 	Long: `
 CodeMeta (https://codemeta.github.io) is a JSON-LD file format used to describe software projects. 
-'codemetagenerator' is an interactive tool that helps you generate a valid 'codemeta.json' file.`,
+'codemetagenerator' is an interactive tool that helps you generate a valid 'codemeta.json' file.
+
+The configuration, the cached SPDX licenses and the drafts are kept in the
+"codemetagenerator" directories of $XDG_CONFIG_HOME, $XDG_CACHE_HOME and
+$XDG_STATE_HOME when set, and in $HOME/.codemetagenerator otherwise. Pass --home or set
+CODEMETAGENERATOR_HOME to keep all of them in the .codemetagenerator directory of
+another directory instead, e.g., to isolate the state of a CI job.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		initConfig()
+		if dirsErr != nil {
+			return dirsErr
+		}
+		if configErr != nil {
+			return fmt.Errorf("invalid configuration: %s", configErr.Error())
+		}
+		err := utils.MkHomeDir(utils.UserHomeDir)
+		if err != nil {
+			return err
+		}
+		if Draft != "" {
+			err := utils.ValidDraftName(Draft)
+			if err != nil {
+				return err
+			}
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		err = resolveProjectFile(workingDir)
+		if err != nil {
+			return err
+		}
 		foundLicenses, err := loadSupportedLicenses(utils.UserHomeDir, utils.MkHttpClient())
 		if err != nil {
 			return fmt.Errorf("unable to retrieve supported licenses: %s", err.Error())
@@ -130,8 +315,6 @@ func Execute() {
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
-
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
@@ -142,8 +325,77 @@ func init() {
 	rootCmd.CompletionOptions.DisableDescriptions = true
 
 	rootCmd.PersistentFlags().BoolVarP(&Debug, "debug", "d", false, "enable debug mode")
+	rootCmd.PersistentFlags().StringVar(&Draft, "draft", "", "name of the draft to operate on. If not specified, the current draft will be used.")
+	rootCmd.PersistentFlags().StringVar(&ProjectFile, "file", "", "path to a project 'codemeta.json' file to edit in place instead of an in-progress draft.")
+	rootCmd.PersistentFlags().BoolVar(&NoInput, "no-input", false, "never prompt, fail instead when a value has not been given with a flag.")
+	rootCmd.PersistentFlags().BoolVar(&Local, "local", false, "edit the 'codemeta.json' file found in the working directory or the root of its git repository in place.")
+	rootCmd.PersistentFlags().StringVar(&Home, "home", "", "directory to keep the .codemetagenerator directory in, instead of the XDG or $HOME directories. Overrides "+utils.HomeEnv+".")
+	rootCmd.PersistentFlags().BoolVar(&KeepEmpty, "keep-empty", false, "keep empty strings, objects and arrays when writing instead of removing them.")
+	rootCmd.PersistentFlags().StringArrayVarP(&ConfigOverrides, "config", "c", nil, "override a configuration value for this run, e.g., -c http-timeout=10s. May be repeated.")
 }
 
-// initConfig reads in config file and ENV variables if set.
+// initConfig resolves the directories and reads in config file and ENV variables if set.
+// It is run before the commands of the root command, the errors are reported by them.
 func initConfig() {
+	dirs, err := utils.ResolveDirs(Home, os.LookupEnv, os.UserHomeDir)
+	if err != nil {
+		dirsErr = err
+		return
+	}
+	dirs.Use()
+	moveLegacyFiles(dirs)
+	workingDir, err := os.Getwd()
+	if err != nil {
+		configErr = err
+		return
+	}
+	Config, configErr = config.Load(utils.UserHomeDir, workingDir, os.LookupEnv, ConfigOverrides)
+	if configErr != nil {
+		return
+	}
+	applyConfig(Config)
+}
+
+// returns the names of the files of the configuration directory
+func configFileNames() []string {
+	return []string{config.FileName, filepath.Base(utils.GetProfileFilePath("")), filepath.Base(people.GetFilePath(""))}
+}
+
+// moves the configuration and state files kept in $HOME/.codemetagenerator to the XDG
+// directories once their XDG_* variables are set, see utils.MoveLegacyFiles. A file which
+// cannot be moved is reported and left behind.
+func moveLegacyFiles(dirs *utils.Dirs) {
+	moves := []struct {
+		dir   string
+		names []string
+	}{
+		{dirs.Config, configFileNames()},
+		{dirs.State, utils.StateFileNames},
+	}
+	for _, move := range moves {
+		moved, err := utils.MoveLegacyFiles(dirs.Legacy, move.dir, move.names)
+		for _, path := range moved {
+			fmt.Fprintf(os.Stderr, "ℹ️  Moved %s to %s\n", path, move.dir)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", err.Error())
+		}
+	}
+}
+
+// applies the configuration to the package level settings
+func applyConfig(c *config.Config) {
+	utils.SPDXLicensesURL = c.Get(config.SPDXURL)
+	if timeout, err := time.ParseDuration(c.Get(config.HTTPTimeout)); err == nil {
+		utils.HttpTimeout = timeout
+	}
+	model.ConfiguredContext = c.Get(config.Context)
+}
+
+// returns the configured value, or an empty value when no configuration has been loaded
+func configured(name string) string {
+	if Config == nil {
+		return ""
+	}
+	return Config.Get(name)
 }
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// the formats of the exported schema
+const (
+	schemaFormatCUE        = "cue"
+	schemaFormatJSONSchema = "jsonschema"
+)
+
+var schemaFormat string
+var schemaExportVersion string
+
+// returns the schema of the CodeMeta version in the format
+func exportSchema(format string, version string) (string, error) {
+	switch format {
+	case schemaFormatCUE:
+		return cue.Source(version)
+	case schemaFormatJSONSchema:
+		schema, err := cue.JSONSchema(version)
+		if err != nil {
+			return "", err
+		}
+		return utils.FormatJSON(schema), nil
+	}
+	return "", fmt.Errorf("unsupported schema format: %s, must be one of: %s, %s", format, schemaFormatCUE, schemaFormatJSONSchema)
+}
+
+func schemaExport(writer utils.Writer, format string, version string, outFile string) error {
+	if !lo.Contains(model.Versions, version) {
+		return writer.Errorf("unsupported schema version: %s, must be one of: %s", version, strings.Join(model.Versions, ", "))
+	}
+	schema, err := exportSchema(format, version)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to export the schema: %s", err.Error())
+	}
+
+	if outFile != "" {
+		err = utils.WriteFile(outFile, []byte(schema))
+		if err != nil {
+			handleErr(writer, err)
+			return writer.Errorf("unable to write the schema to output file %s", outFile)
+		}
+	} else {
+		writer.Println(schema)
+	}
+	return nil
+}
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Args:  cobra.NoArgs,
+	Short: "Inspect the CodeMeta schemas used for validation [export]",
+	Long: `
+Inspect the CodeMeta schemas used for validation. Each CodeMeta version, ` + strings.Join(model.Versions, " and ") + `,
+has its own CUE schema, e.g., CodeMeta 2.0 names its continuous integration property
+contIntegration instead of continuousIntegration.`,
+	// the schema commands neither need the SPDX licenses nor the configuration
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+}
+
+// schemaExportCmd represents the schema export command, "schema jsonschema" is an alias which
+// exports the JSON Schema
+var schemaExportCmd = &cobra.Command{
+	Use:     "export [--format cue|jsonschema] [--schema-version <version>] [-o | --output <path>]",
+	Aliases: []string{schemaFormatJSONSchema},
+	Args:    cobra.NoArgs,
+	Short:   "Export the effective CUE schema or a generated JSON Schema",
+	Long: `
+Export the effective CUE schema of a CodeMeta version, the shared schema.org types
+followed by the types of the version, or a JSON Schema (draft 2020-12) generated from it
+with --format jsonschema, or "schema jsonschema", e.g., for autocompletion and inline
+errors when editing codemeta.json files. The JSON Schema refers to #SoftwareSourceCode and
+each CUE definition is a schema of "$defs". Matches of case-insensitive values, e.g.,
+#DevelopmentStatus, are enums of the values as given and in lower case, and the regular
+expressions of #ValidURL and #ValidEmail are patterns.
+
+The JSON Schema of CodeMeta ` + model.DefaultVersion + ` is also kept in the schema directory of the
+repository. With VS Code, add to the settings:
+
+  "json.schemas": [
+    {
+      "fileMatch": ["codemeta.json"],
+      "url": "https://raw.githubusercontent.com/cacoco/codemetagenerator/main/schema/codemeta-` + model.DefaultVersion + `.schema.json"
+    }
+  ]
+
+Output can be written to a file [-o | --output <path>] or printed to the console.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		writer := &utils.StdoutWriter{}
+		format := schemaFormat
+		if cmd.CalledAs() == schemaFormatJSONSchema {
+			if cmd.Flags().Changed("format") && format != schemaFormatJSONSchema {
+				return writer.Errorf("schema %s exports the JSON Schema, use schema export --format %s instead", schemaFormatJSONSchema, format)
+			}
+			format = schemaFormatJSONSchema
+		}
+		return schemaExport(writer, format, schemaExportVersion, outputFile)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaExportCmd)
+
+	schemaExportCmd.Flags().StringVar(&schemaFormat, "format", schemaFormatCUE, fmt.Sprintf("format of the schema, one of: %s, %s", schemaFormatCUE, schemaFormatJSONSchema))
+	schemaExportCmd.Flags().StringVar(&schemaExportVersion, "schema-version", model.DefaultVersion, fmt.Sprintf("the CodeMeta version of the schema, one of: %s", strings.Join(model.Versions, ", ")))
+	schemaExportCmd.Flags().StringVarP(&outputFile, "output", "o", "", "path to the output file. If not specified, the schema will be printed to the console.")
+}
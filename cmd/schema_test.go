@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestSchemaExport(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	writer := &utils.TestWriter{}
+
+	path := filepath.Join(temp, "codemeta-2.0.cue")
+	err := schemaExport(writer, schemaFormatCUE, model.CodeMeta20, path)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g.Ω(string(bytes)).Should(gomega.ContainSubstring("#Context: \"https://doi.org/10.5063/schema/codemeta-2.0\""))
+	g.Ω(string(bytes)).Should(gomega.ContainSubstring("contIntegration?:"))
+
+	path = filepath.Join(temp, "codemeta.schema.json")
+	err = schemaExport(writer, schemaFormatJSONSchema, model.CodeMeta30, path)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	bytes, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var schema map[string]any
+	g.Expect(json.Unmarshal(bytes, &schema)).To(gomega.BeNil())
+	g.Ω(schema["$ref"]).Should(gomega.Equal("#/$defs/SoftwareSourceCode"))
+	g.Ω(schema["$schema"]).Should(gomega.Equal("https://json-schema.org/draft/2020-12/schema"))
+	g.Ω(schema["$defs"]).Should(gomega.HaveKeyWithValue("DevelopmentStatus", gomega.HaveKey("enum")))
+
+	g.Expect(schemaExport(writer, "yaml", model.CodeMeta30, "")).ToNot(gomega.BeNil())
+	g.Expect(schemaExport(writer, schemaFormatCUE, "1.0", "")).ToNot(gomega.BeNil())
+}
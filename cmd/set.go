@@ -14,7 +14,7 @@ func set(basedir string, args []string) error {
 	path := args[0]
 	value := args[1]
 
-	bytes, err := utils.LoadFile(utils.GetInProgressFilePath(basedir))
+	bytes, err := utils.LoadFile(getInProgressFilePath(basedir))
 	if err != nil {
 		return fmt.Errorf("unable to load the in-progress codemeta.json file: %s", err.Error())
 	}
@@ -24,7 +24,7 @@ func set(basedir string, args []string) error {
 		return err
 	}
 
-	return utils.MarshalBytes(utils.GetInProgressFilePath(basedir), []byte(*result))
+	return saveInProgressFile(getInProgressFilePath(basedir), []byte(*result))
 }
 
 func setValue(jsonBytes []byte, path string, value string) (*string, error) {
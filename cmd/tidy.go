@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// removes the empty strings, objects and arrays of the file in place, or of the in-progress
+// file when no file is given. With check the file is left as is and its empty values are
+// reported as an error, e.g., in CI.
+func tidy(writer utils.Writer, basedir string, path string, check bool) error {
+	inProgress := path == ""
+	if inProgress {
+		path = getInProgressFilePath(basedir)
+	}
+	bytes, err := utils.LoadFile(path)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to read file %s", path)
+	}
+
+	tidied, removed, err := utils.Tidy(bytes)
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to parse file %s as JSON", path)
+	}
+	if len(removed) == 0 {
+		writer.Println(fmt.Sprintf("✅ %s has no empty values.", path))
+		return nil
+	}
+	if check {
+		return writer.Errorf("%s has %d empty value(s): %s", path, len(removed), strings.Join(removed, ", "))
+	}
+
+	// the keys keep their order, the file is formatted as the .editorconfig files of its
+	// git repository specify
+	if inProgress {
+		err = saveInProgressFile(path, tidied)
+	} else {
+		err = utils.MarshalBytesFormatted(path, tidied, utils.FormatOf(path))
+	}
+	if err != nil {
+		handleErr(writer, err)
+		return writer.Errorf("unable to write file %s: %v", path, err)
+	}
+
+	writer.Println(fmt.Sprintf("🧹 Removed %d empty value(s) from %s:", len(removed), path))
+	for _, p := range removed {
+		writer.Println("\t" + p)
+	}
+	return nil
+}
+
+var tidyCheck bool
+
+// tidyCmd represents the tidy command
+var tidyCmd = &cobra.Command{
+	Use:   "tidy [<path/to/codemeta.json>] [--check]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Remove the empty values of a codemeta.json file or of the in-progress file",
+	Long: `
+Remove the empty strings, empty objects and empty arrays of a codemeta.json file in place,
+e.g., the "runtimePlatform": "" or "@id": "" of prompts answered by pressing Enter. Objects
+and arrays which only hold empty values are removed as well. If no file is specified, the
+in-progress file is tidied. The removed values are listed with their path, see the path
+syntax of "set" and "delete".
+
+The keys keep their order. A codemeta.json file inside a git repository is formatted as
+the .editorconfig files of the repository specify, see "generate".
+
+Use --check to leave the file as is and fail when it has empty values, e.g., in CI.
+
+The other commands remove empty values before every write unless the global --keep-empty
+flag is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+		return tidy(&utils.StdoutWriter{}, utils.UserHomeDir, path, tidyCheck)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tidyCmd)
+
+	tidyCmd.Flags().BoolVar(&tidyCheck, "check", false, "do not write the file, fail when it has empty values instead")
+}
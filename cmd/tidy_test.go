@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestTidyFile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	path := temp + "/codemeta.json"
+	err := utils.WriteJSON(path, `{"name": "a", "runtimePlatform": "", "@type": "SoftwareSourceCode", "author": [{"@id": "", "@type": "Person"}]}`)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	writer := utils.TestWriter{}
+
+	// the file is left as is with --check
+	err = tidy(&writer, temp, path, true)
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Ω(err.Error()).Should(gomega.ContainSubstring("2 empty value(s): runtimePlatform, author.0.@id"))
+
+	err = tidy(&writer, temp, path, false)
+	g.Expect(err).To(gomega.BeNil())
+	bytes, _ := os.ReadFile(path)
+	// the keys keep their order
+	g.Ω(string(bytes)).Should(gomega.Equal("{\n  \"name\": \"a\",\n  \"@type\": \"SoftwareSourceCode\",\n  \"author\": [\n    {\n      \"@type\": \"Person\"\n    }\n  ]\n}"))
+
+	err = tidy(&writer, temp, path, true)
+	g.Expect(err).To(gomega.BeNil())
+}
+
+func TestTidyInProgressFile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	err := utils.WriteJSON(utils.GetInProgressFilePath(temp), `{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", "license": "", "keywords": []}`)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	writer := utils.TestWriter{}
+
+	err = tidy(&writer, temp, "", false)
+	g.Expect(err).To(gomega.BeNil())
+	m, err := utils.Unmarshal(utils.GetInProgressFilePath(temp))
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(*m).Should(gomega.Equal(map[string]any{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode"}))
+}
+
+func TestGenerateRemovesEmptyValues(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+	err := utils.WriteJSON(utils.GetInProgressFilePath(temp), `{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", "name": "a", "readme": "", "maintainer": {"@type": "Person", "@id": "", "email": ""}}`)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	output := temp + "/codemeta.json"
+	writer := utils.TestWriter{}
+
+	// the empty "@id" and "email" would not be valid
+	err = generate(temp, &writer, output, utils.OrderCanonical, nil)
+	g.Expect(err).To(gomega.BeNil())
+	m, err := utils.Unmarshal(output)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(*m).Should(gomega.Equal(map[string]any{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", "name": "a", "maintainer": map[string]any{"@type": "Person"}}))
+
+	KeepEmpty = true
+	defer func() { KeepEmpty = false }()
+	err = generate(temp, &writer, output, utils.OrderCanonical, nil)
+	g.Expect(err).NotTo(gomega.BeNil())
+}
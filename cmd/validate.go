@@ -3,30 +3,45 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
-	"github.com/cacoco/codemetagenerator/internal/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
 	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/cacoco/codemetagenerator/pkg/validation"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
-func validate(basedir string, writer utils.Writer, inFile string) error {
+// the CodeMeta version of the schema, detected from the "@context" when empty
+var schemaVersion string
+
+func validate(basedir string, writer utils.Writer, inFile string, version string) error {
+	if version != "" && !lo.Contains(model.Versions, version) {
+		return writer.Errorf("unsupported schema version: %s, must be one of: %s", version, strings.Join(model.Versions, ", "))
+	}
 	var path string
 	if inFile == "" {
-		path = utils.GetInProgressFilePath(basedir)
+		path = getInProgressFilePath(basedir)
 	} else {
 		path = inFile
 	}
-	json, err := utils.ReadJSON(path)
+	bytes, err := utils.LoadFile(path)
 	if err != nil {
 		handleErr(writer, err)
 		return writer.Errorf("unable to read codemeta.inprogress.json file, ensure you have run `codemetagenerator new` at least once or specify a file with the --input flag")
 	}
 
-	// validate the file
-	err = cue.Validate([]byte(*json))
+	// validate the file, reporting the path and position of each problem
+	diagnostics, err := validation.ValidateVersion(bytes, version)
 	if err != nil {
 		handleErr(writer, err)
-		return writer.Errorf("invalid codemeta.json file: %v", err)
+		return writer.Errorf("unable to validate codemeta.json file: %v", err)
+	}
+	for _, diagnostic := range diagnostics {
+		writer.Println(fmt.Sprintf("❌ %s", diagnostic))
+	}
+	if !validation.Valid(diagnostics) {
+		return writer.Errorf("invalid codemeta.json file '%s': %d problem(s) found", filepath.Base(path), len(diagnostics))
 	}
 
 	writer.Println(fmt.Sprintf("✅ The codemeta file '%s' is valid.", filepath.Base(path)))
@@ -37,9 +52,20 @@ func validate(basedir string, writer utils.Writer, inFile string) error {
 var validateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validates a codemeta.json file",
-	Long:  `Validates a codemeta.json file. If no input file is specified, the current in progress file will be used.`,
+	Long: `
+Validates a codemeta.json file. If no input file is specified, the current in progress file
+will be used. Each problem is reported with the JSON Pointer of the offending value and its
+line and column in the file, e.g.,
+
+❌ /author/0/email (12:16): invalid value "jane" (out of bound ...)
+
+The file is validated against the schema of its CodeMeta version, which is detected from its
+"@context": 2.0 for "https://doi.org/10.5063/schema/codemeta-2.0", 3.0 otherwise. Use the
+--schema-version flag to validate against the schema of another version, e.g., before
+upgrading a CodeMeta 2.0 file, which names its continuous integration property
+contIntegration instead of continuousIntegration.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return validate(utils.UserHomeDir, &utils.StdoutWriter{}, inputFile)
+		return validate(utils.UserHomeDir, &utils.StdoutWriter{}, inputFile, schemaVersion)
 	},
 }
 
@@ -47,4 +73,5 @@ func init() {
 	rootCmd.AddCommand(validateCmd)
 
 	validateCmd.Flags().StringVarP(&inputFile, "input", "i", "", "path to an input 'codemeta.json' file. If not specified, the current in progress file will be used.")
+	validateCmd.Flags().StringVar(&schemaVersion, "schema-version", "", fmt.Sprintf("the CodeMeta version of the schema, one of: %s. If not specified, it is detected from the '@context' of the file.", strings.Join(model.Versions, ", ")))
 }
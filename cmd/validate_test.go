@@ -32,7 +32,7 @@ func Test_ExecuteValidateCmd(t *testing.T) {
 	writer := &utils.TestWriter{}
 
 	validate := &cobra.Command{Use: "validate", RunE: func(cmd *cobra.Command, args []string) error {
-		return validate(temp, writer, "")
+		return validate(temp, writer, "", "")
 	},
 	}
 	buf := bytes.NewBufferString("")
@@ -69,7 +69,7 @@ func Test_ExecuteValidateCmdBadFile(t *testing.T) {
 	writer := &utils.TestWriter{}
 
 	validate := &cobra.Command{Use: "validate", RunE: func(cmd *cobra.Command, args []string) error {
-		return validate(temp, writer, "")
+		return validate(temp, writer, "", "")
 	},
 	}
 	buf := bytes.NewBufferString("")
@@ -82,3 +82,39 @@ func Test_ExecuteValidateCmdBadFile(t *testing.T) {
 		t.Errorf("expected error for validate command")
 	}
 }
+
+func Test_ExecuteValidateCmdSchemaVersion(t *testing.T) {
+	temp := t.TempDir()
+	// setup
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+
+	testMap := map[string]any{
+		model.Context:     "https://doi.org/10.5063/schema/codemeta-2.0",
+		model.Type:        model.SoftwareSourceCodeType,
+		model.Description: "description",
+		"contIntegration": "https://url.org",
+	}
+
+	inProgressFilePath := utils.GetInProgressFilePath(temp)
+	err := utils.Marshal(inProgressFilePath, testMap)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	writer := &utils.TestWriter{}
+
+	// the version is detected from the "@context"
+	err = validate(temp, writer, "", "")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	// contIntegration is not a CodeMeta 3.0 property
+	err = validate(temp, writer, "", model.CodeMeta30)
+	if err == nil {
+		t.Errorf("expected error for validate command")
+	}
+	err = validate(temp, writer, "", "1.0")
+	if err == nil {
+		t.Errorf("expected error for validate command")
+	}
+}
@@ -0,0 +1,293 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SPDXURL     = "spdx-url"
+	HTTPTimeout = "http-timeout"
+	Context     = "context"
+	License     = "license"
+
+	// the user configuration file in the configuration directory, see utils.ResolveDirs
+	FileName = "config.yaml"
+	// the repository configuration file in the working directory or the root of its git
+	// repository
+	RepoFileName = ".codemetagenerator.yaml"
+	EnvPrefix    = "CODEMETAGENERATOR_"
+
+	SourceDefault = "default"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// Key is a configuration key
+type Key struct {
+	Name        string
+	Description string
+	Default     string
+	Validate    func(string) error
+}
+
+// returns the environment variable of the key, e.g., CODEMETAGENERATOR_HTTP_TIMEOUT
+func (k Key) Env() string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(k.Name, "-", "_"))
+}
+
+func validTimeout(value string) error {
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return fmt.Errorf("invalid duration: %s, e.g., 2s or 1m", value)
+	}
+	return nil
+}
+
+func validContext(value string) error {
+	for _, context := range model.Contexts {
+		if value == context {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported context: %s, must be one of: %s", value, strings.Join(model.Contexts, ", "))
+}
+
+// the directories are not configured with a key, as the user configuration file is read
+// from one of them, see utils.ResolveDirs
+var Keys = []Key{
+	{Name: SPDXURL, Description: "URL of the SPDX license list JSON", Default: utils.DefaultSPDXLicensesURL, Validate: utils.ValidUrl},
+	{Name: HTTPTimeout, Description: "timeout of HTTP requests", Default: utils.DefaultHttpTimeout.String(), Validate: validTimeout},
+	{Name: Context, Description: "@context of new codemeta.json files", Default: model.DefaultContext, Validate: validContext},
+	{Name: License, Description: "SPDX license ID offered by \"new\"", Validate: utils.Nop},
+}
+
+func LookupKey(name string) (Key, error) {
+	for _, key := range Keys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+	names := make([]string, len(Keys))
+	for i, key := range Keys {
+		names[i] = key.Name
+	}
+	return Key{}, fmt.Errorf("unknown configuration key: %s, must be one of: %s", name, strings.Join(names, ", "))
+}
+
+// Value is a configuration value and where it was set: "default", the path of a
+// configuration file, "env" or "flag"
+type Value struct {
+	Value  string
+	Source string
+}
+
+// Config holds the configuration values, layered in increasing precedence: the
+// defaults, the user file, the repository file, the environment and the flags
+type Config struct {
+	values map[string]Value
+}
+
+// returns the default configuration
+func New() *Config {
+	c := &Config{values: map[string]Value{}}
+	for _, key := range Keys {
+		c.values[key.Name] = Value{Value: key.Default, Source: SourceDefault}
+	}
+	return c
+}
+
+func (c *Config) Get(name string) string {
+	return c.values[name].Value
+}
+
+func (c *Config) Lookup(name string) (Value, error) {
+	if _, err := LookupKey(name); err != nil {
+		return Value{}, err
+	}
+	return c.values[name], nil
+}
+
+// sets the value of a layer. An empty value is unset, as "config set" removes it, so that
+// the value of the lower layers or the default still applies.
+func (c *Config) set(name string, value string, source string) error {
+	key, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return nil
+	}
+	if err := key.Validate(value); err != nil {
+		return fmt.Errorf("invalid value for %s: %s", name, err.Error())
+	}
+	c.values[name] = Value{Value: value, Source: source}
+	return nil
+}
+
+// layers the values of the configuration file, a missing file is skipped
+func (c *Config) LoadFile(path string) error {
+	values, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for name, value := range values {
+		err := c.set(name, value, path)
+		if err != nil {
+			return fmt.Errorf("%s: %s", path, err.Error())
+		}
+	}
+	return nil
+}
+
+// layers the CODEMETAGENERATOR_* environment variables
+func (c *Config) LoadEnv(lookupEnv func(string) (string, bool)) error {
+	for _, key := range Keys {
+		if value, ok := lookupEnv(key.Env()); ok {
+			err := c.set(key.Name, value, SourceEnv)
+			if err != nil {
+				return fmt.Errorf("%s: %s", key.Env(), err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// layers the name=value overrides given with flags
+func (c *Config) LoadFlags(overrides []string) error {
+	for _, override := range overrides {
+		name, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return fmt.Errorf("invalid configuration override: %s, expected name=value", override)
+		}
+		err := c.set(strings.TrimSpace(name), strings.TrimSpace(value), SourceFlag)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns the user configuration file in the configuration directory of basedir
+func GetFilePath(basedir string) string {
+	return utils.GetConfigDir(basedir) + "/" + FileName
+}
+
+// returns the repository configuration file in the directory, or else in the root of its
+// git repository, and whether it exists. When it does not exist, the path to create it
+// at is returned.
+func FindRepoFile(dir string) (string, bool) {
+	candidate := filepath.Join(dir, RepoFileName)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate, true
+	}
+	root, err := utils.FindGitRoot(dir)
+	if err != nil {
+		return candidate, false
+	}
+	candidate = filepath.Join(root, RepoFileName)
+	_, err = os.Stat(candidate)
+	return candidate, err == nil
+}
+
+// loads the layered configuration of the basedir and the working directory
+func Load(basedir string, workingDir string, lookupEnv func(string) (string, bool), overrides []string) (*Config, error) {
+	c := New()
+	err := c.LoadFile(GetFilePath(basedir))
+	if err != nil {
+		return nil, err
+	}
+	if path, ok := FindRepoFile(workingDir); ok {
+		err = c.LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = c.LoadEnv(lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	err = c.LoadFlags(overrides)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// reads the values of a configuration file, a missing file has no values
+func ReadFile(path string) (map[string]string, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]any
+	err = yaml.Unmarshal(bytes, &document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", path, err.Error())
+	}
+	values := map[string]string{}
+	for name, value := range document {
+		switch value.(type) {
+		case map[string]any, []any:
+			return nil, fmt.Errorf("%s: expected a single value for %s", path, name)
+		case nil:
+			values[name] = ""
+		default:
+			values[name] = fmt.Sprint(value)
+		}
+	}
+	return values, nil
+}
+
+// sets the value in the configuration file, an empty value removes it
+func SetValue(path string, name string, value string) error {
+	key, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+	if value != "" {
+		if err := key.Validate(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %s", name, err.Error())
+		}
+	}
+	values, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		delete(values, name)
+	} else {
+		values[name] = value
+	}
+
+	var b strings.Builder
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		node := yaml.Node{Kind: yaml.ScalarNode, Value: values[name]}
+		bytes, err := yaml.Marshal(&node)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s: %s", name, bytes)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
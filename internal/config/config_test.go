@@ -0,0 +1,132 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func TestLoad(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	home := t.TempDir()
+	repo := t.TempDir()
+	userFile := GetFilePath(home)
+	err := SetValue(userFile, HTTPTimeout, "5s")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	SetValue(userFile, License, "MIT")
+	SetValue(userFile, Context, "https://doi.org/10.5063/schema/codemeta-2.0")
+	os.WriteFile(filepath.Join(repo, RepoFileName), []byte("license: Apache-2.0\nhttp-timeout: 10s\n"), 0644)
+
+	// the defaults
+	c, err := Load(t.TempDir(), t.TempDir(), env(nil), nil)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(c.Get(SPDXURL)).Should(gomega.Equal(utils.DefaultSPDXLicensesURL))
+	g.Ω(c.Get(Context)).Should(gomega.Equal(model.DefaultContext))
+	g.Ω(c.Get(HTTPTimeout)).Should(gomega.Equal("2s"))
+
+	c, err = Load(home, repo, env(map[string]string{"CODEMETAGENERATOR_HTTP_TIMEOUT": "20s"}), []string{"license=BSD-3-Clause"})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(c.Lookup(Context)).Should(gomega.Equal(Value{Value: "https://doi.org/10.5063/schema/codemeta-2.0", Source: userFile}))
+	g.Ω(c.Lookup(HTTPTimeout)).Should(gomega.Equal(Value{Value: "20s", Source: SourceEnv}))
+	g.Ω(c.Lookup(License)).Should(gomega.Equal(Value{Value: "BSD-3-Clause", Source: SourceFlag}))
+
+	// the repository file overrides the user file
+	c, err = Load(home, repo, env(nil), nil)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(c.Lookup(License)).Should(gomega.Equal(Value{Value: "Apache-2.0", Source: filepath.Join(repo, RepoFileName)}))
+}
+
+func TestLoadEmptyValues(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	home := t.TempDir()
+	repo := t.TempDir()
+	SetValue(GetFilePath(home), License, "MIT")
+	os.WriteFile(filepath.Join(repo, RepoFileName), []byte("context:\nlicense: \"\"\n"), 0644)
+
+	// empty values are unset, the lower layers and the defaults still apply
+	c, err := Load(home, repo, env(map[string]string{"CODEMETAGENERATOR_SPDX_URL": ""}), []string{"context=", "http-timeout= "})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(c.Lookup(SPDXURL)).Should(gomega.Equal(Value{Value: utils.DefaultSPDXLicensesURL, Source: SourceDefault}))
+	g.Ω(c.Lookup(Context)).Should(gomega.Equal(Value{Value: model.DefaultContext, Source: SourceDefault}))
+	g.Ω(c.Lookup(HTTPTimeout)).Should(gomega.Equal(Value{Value: "2s", Source: SourceDefault}))
+	g.Ω(c.Lookup(License)).Should(gomega.Equal(Value{Value: "MIT", Source: GetFilePath(home)}))
+}
+
+func TestLoadInvalid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	_, err := Load(t.TempDir(), t.TempDir(), env(map[string]string{"CODEMETAGENERATOR_HTTP_TIMEOUT": "soon"}), nil)
+	g.Expect(err).ToNot(gomega.BeNil())
+	_, err = Load(t.TempDir(), t.TempDir(), env(nil), []string{"unknown=value"})
+	g.Expect(err).ToNot(gomega.BeNil())
+	_, err = Load(t.TempDir(), t.TempDir(), env(nil), []string{"license"})
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	repo := t.TempDir()
+	os.WriteFile(filepath.Join(repo, RepoFileName), []byte("context: https://example.org\n"), 0644)
+	_, err = Load(t.TempDir(), repo, env(nil), nil)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestSetValue(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), RepoFileName)
+	err := SetValue(path, SPDXURL, "https://example.org/licenses.json")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = SetValue(path, License, "MIT")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	bytes, _ := os.ReadFile(path)
+	g.Ω(string(bytes)).Should(gomega.Equal("license: MIT\nspdx-url: https://example.org/licenses.json\n"))
+
+	// an empty value removes the key
+	err = SetValue(path, License, "")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	values, err := ReadFile(path)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(values).Should(gomega.Equal(map[string]string{SPDXURL: "https://example.org/licenses.json"}))
+
+	g.Expect(SetValue(path, "unknown", "value")).ToNot(gomega.BeNil())
+	g.Expect(SetValue(path, SPDXURL, "not a url")).ToNot(gomega.BeNil())
+}
+
+func TestKeyEnv(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	key, err := LookupKey(HTTPTimeout)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(key.Env()).Should(gomega.Equal("CODEMETAGENERATOR_HTTP_TIMEOUT"))
+}
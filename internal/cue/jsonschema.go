@@ -0,0 +1,400 @@
+package cue
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/literal"
+	"cuelang.org/go/cue/parser"
+	cuetoken "cuelang.org/go/cue/token"
+	"github.com/samber/lo"
+)
+
+// the JSON Schema dialect of the generated schemas
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// the "$id" of the generated schema of a version, the schemas are checked in to the
+// schema directory of the repository, e.g., for editors to refer to
+const jsonSchemaId = "https://raw.githubusercontent.com/cacoco/codemetagenerator/main/schema/codemeta-%s.schema.json"
+
+// a case-insensitive regular expression of a single value, e.g., "(?i)^Active$"
+var caseInsensitiveValue = regexp.MustCompile(`^\(\?i\)\^([^^$\\.*+?()\[\]{}|]+)\$$`)
+
+// generates JSON Schemas of the CUE definitions, each definition is a schema of "$defs"
+type generator struct {
+	definitions map[string]ast.Expr
+}
+
+// returns a JSON Schema of the CodeMeta version, generated from its CUE schema. Each CUE
+// definition, e.g., #Person, is a schema of "$defs" and the document refers to
+// #SoftwareSourceCode. Embedded definitions, e.g., the #Thing of #Person, are merged into
+// the definition embedding them, a property of the embedding definition replaces the one
+// of the embedded definition.
+func JSONSchema(version string) (map[string]any, error) {
+	source, err := Source(version)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parser.ParseFile("codemeta-"+version+".cue", source)
+	if err != nil {
+		return nil, err
+	}
+
+	g := generator{definitions: map[string]ast.Expr{}}
+	var document ast.Expr
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.Field:
+			name, _, err := ast.LabelName(d.Label)
+			if err != nil {
+				return nil, err
+			}
+			g.definitions[strings.TrimPrefix(name, "#")] = d.Value
+		case *ast.EmbedDecl:
+			document = d.Expr
+			// refer to the definition of {#SoftwareSourceCode}
+			if s, ok := document.(*ast.StructLit); ok && len(s.Elts) == 1 {
+				if embed, ok := s.Elts[0].(*ast.EmbedDecl); ok {
+					document = embed.Expr
+				}
+			}
+		}
+	}
+	if document == nil {
+		return nil, fmt.Errorf("the schema of CodeMeta %s does not embed the document definition", version)
+	}
+
+	schema, err := g.schema(document)
+	if err != nil {
+		return nil, err
+	}
+	defs := map[string]any{}
+	for name, expr := range g.definitions {
+		def, err := g.schema(expr)
+		if err != nil {
+			return nil, fmt.Errorf("#%s: %s", name, err.Error())
+		}
+		defs[name] = def
+	}
+	schema["$schema"] = JSONSchemaDialect
+	schema["$id"] = fmt.Sprintf(jsonSchemaId, version)
+	schema["title"] = "CodeMeta " + version
+	schema["$defs"] = defs
+	return schema, nil
+}
+
+// returns the JSON Schema of the expression
+func (g *generator) schema(expr ast.Expr) (map[string]any, error) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return g.schema(e.X)
+	case *ast.Ident:
+		return g.ident(e)
+	case *ast.BasicLit:
+		return basicLit(e)
+	case *ast.StructLit:
+		return g.object(e)
+	case *ast.ListLit:
+		return g.list(e)
+	case *ast.CallExpr:
+		return call(e)
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case cuetoken.MAT:
+			return match(e.X)
+		case cuetoken.MUL:
+			// a default value is only meaningful in a disjunction
+			return g.schema(e.X)
+		}
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case cuetoken.OR:
+			return g.disjunction(e)
+		case cuetoken.AND:
+			return g.conjunction(e)
+		}
+	}
+	return nil, fmt.Errorf("unsupported CUE expression: %T", expr)
+}
+
+func (g *generator) ident(e *ast.Ident) (map[string]any, error) {
+	if name, ok := strings.CutPrefix(e.Name, "#"); ok {
+		if _, ok := g.definitions[name]; !ok {
+			return nil, fmt.Errorf("undefined definition: %s", e.Name)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}, nil
+	}
+	switch e.Name {
+	case "string":
+		return map[string]any{"type": "string"}, nil
+	case "int":
+		return map[string]any{"type": "integer"}, nil
+	case "float", "number":
+		return map[string]any{"type": "number"}, nil
+	case "bool":
+		return map[string]any{"type": "boolean"}, nil
+	case "_":
+		return map[string]any{}, nil
+	}
+	return nil, fmt.Errorf("unsupported CUE identifier: %s", e.Name)
+}
+
+func basicLit(e *ast.BasicLit) (map[string]any, error) {
+	switch e.Kind {
+	case cuetoken.STRING:
+		s, err := literal.Unquote(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"const": s}, nil
+	case cuetoken.TRUE, cuetoken.FALSE:
+		return map[string]any{"const": e.Kind == cuetoken.TRUE}, nil
+	}
+	return nil, fmt.Errorf("unsupported CUE literal: %s", e.Value)
+}
+
+// returns the schema of a regular expression match, e.g., =~ "^[a-z]+$" is a "pattern"
+func match(expr ast.Expr) (map[string]any, error) {
+	pattern, err := matchPattern(expr)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": "string", "pattern": pattern}, nil
+}
+
+func matchPattern(expr ast.Expr) (string, error) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != cuetoken.STRING {
+		return "", fmt.Errorf("unsupported CUE regular expression: %T", expr)
+	}
+	return literal.Unquote(lit.Value)
+}
+
+// returns the values of a disjunction of matches of case-insensitive values, e.g., of
+// =~"(?i)^Active$" | =~"(?i)^WIP$", as given and in lower case
+func enum(alternatives []ast.Expr) ([]any, bool) {
+	values := []any{}
+	for _, alternative := range alternatives {
+		unary, ok := alternative.(*ast.UnaryExpr)
+		if !ok || unary.Op != cuetoken.MAT {
+			return nil, false
+		}
+		pattern, err := matchPattern(unary.X)
+		if err != nil {
+			return nil, false
+		}
+		found := caseInsensitiveValue.FindStringSubmatch(pattern)
+		if found == nil {
+			return nil, false
+		}
+		values = append(values, found[1], strings.ToLower(found[1]))
+	}
+	return lo.Uniq(values), true
+}
+
+// returns the format of a time.Format call, e.g., "date" for time.Format("2006-01-02")
+func call(e *ast.CallExpr) (map[string]any, error) {
+	fun, ok := e.Fun.(*ast.SelectorExpr)
+	if !ok || len(e.Args) != 1 || fmt.Sprint(fun.X) != "time" || fmt.Sprint(fun.Sel) != "Format" {
+		return nil, fmt.Errorf("unsupported CUE call")
+	}
+	layout := ""
+	switch arg := e.Args[0].(type) {
+	case *ast.SelectorExpr:
+		layout = fmt.Sprint(arg.Sel)
+	case *ast.BasicLit:
+		layout, _ = literal.Unquote(arg.Value)
+	}
+	switch layout {
+	case "RFC3339":
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case "2006-01-02":
+		return map[string]any{"type": "string", "format": "date"}, nil
+	}
+	return map[string]any{"type": "string", "format": "time"}, nil
+}
+
+// returns the schema of an open list, e.g., [...string]
+func (g *generator) list(e *ast.ListLit) (map[string]any, error) {
+	if len(e.Elts) != 1 {
+		return nil, fmt.Errorf("unsupported CUE list")
+	}
+	ellipsis, ok := e.Elts[0].(*ast.Ellipsis)
+	if !ok {
+		return nil, fmt.Errorf("unsupported CUE list")
+	}
+	if ellipsis.Type == nil {
+		return map[string]any{"type": "array"}, nil
+	}
+	items, err := g.schema(ellipsis.Type)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": "array", "items": items}, nil
+}
+
+// returns the operands of a binary expression of the operator, e.g., of a | b | c
+func operands(expr ast.Expr, op cuetoken.Token) []ast.Expr {
+	if e, ok := expr.(*ast.BinaryExpr); ok && e.Op == op {
+		return append(operands(e.X, op), operands(e.Y, op)...)
+	}
+	return []ast.Expr{expr}
+}
+
+// returns an "anyOf" of the alternatives, the alternative marked as the default, e.g.,
+// *"CreativeWork", is the default value. Matches of case-insensitive values, e.g.,
+// #DevelopmentStatus, are an "enum".
+func (g *generator) disjunction(e *ast.BinaryExpr) (map[string]any, error) {
+	ops := operands(e, cuetoken.OR)
+	if values, ok := enum(ops); ok {
+		return map[string]any{"type": "string", "enum": values}, nil
+	}
+	alternatives := []any{}
+	var defaultValue any
+	for _, operand := range ops {
+		alternative, err := g.schema(operand)
+		if err != nil {
+			return nil, err
+		}
+		if unary, ok := operand.(*ast.UnaryExpr); ok && unary.Op == cuetoken.MUL {
+			defaultValue = alternative["const"]
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	schema := map[string]any{"anyOf": alternatives}
+	if defaultValue != nil {
+		schema["default"] = defaultValue
+	}
+	return schema, nil
+}
+
+// returns the merged object of struct operands, e.g., #Thing & {"@type": "Person"}, and
+// an "allOf" of the operands otherwise
+func (g *generator) conjunction(e *ast.BinaryExpr) (map[string]any, error) {
+	ops := operands(e, cuetoken.AND)
+	if lo.EveryBy(ops, g.isStruct) {
+		o := newObject()
+		for _, operand := range ops {
+			err := g.embed(o, operand)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return o.schema(), nil
+	}
+	all := []any{}
+	for _, operand := range ops {
+		schema, err := g.schema(operand)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, schema)
+	}
+	return map[string]any{"allOf": all}, nil
+}
+
+// reports whether the expression is a struct, or a definition of a struct
+func (g *generator) isStruct(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return g.isStruct(e.X)
+	case *ast.StructLit:
+		return true
+	case *ast.Ident:
+		if name, ok := strings.CutPrefix(e.Name, "#"); ok {
+			definition, ok := g.definitions[name]
+			return ok && g.isStruct(definition)
+		}
+	}
+	return false
+}
+
+// the properties of an object schema in the making
+type object struct {
+	properties map[string]any
+	required   map[string]bool
+	open       bool
+}
+
+func newObject() *object {
+	return &object{properties: map[string]any{}, required: map[string]bool{}}
+}
+
+func (o *object) schema() map[string]any {
+	schema := map[string]any{"type": "object", "properties": o.properties}
+	if len(o.required) > 0 {
+		required := lo.Keys(o.required)
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	if !o.open {
+		// CUE definitions are closed
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+// returns the object schema of the struct
+func (g *generator) object(s *ast.StructLit) (map[string]any, error) {
+	o := newObject()
+	err := g.fields(o, s)
+	if err != nil {
+		return nil, err
+	}
+	return o.schema(), nil
+}
+
+// adds the fields of the struct to the object
+func (g *generator) fields(o *object, s *ast.StructLit) error {
+	for _, elt := range s.Elts {
+		switch e := elt.(type) {
+		case *ast.Field:
+			name, _, err := ast.LabelName(e.Label)
+			if err != nil {
+				return err
+			}
+			schema, err := g.schema(e.Value)
+			if err != nil {
+				return fmt.Errorf("%s: %s", name, err.Error())
+			}
+			o.properties[name] = schema
+			if e.Constraint == cuetoken.OPTION {
+				delete(o.required, name)
+			} else {
+				o.required[name] = true
+			}
+		case *ast.EmbedDecl:
+			for _, operand := range operands(e.Expr, cuetoken.AND) {
+				err := g.embed(o, operand)
+				if err != nil {
+					return err
+				}
+			}
+		case *ast.Ellipsis:
+			o.open = true
+		default:
+			return fmt.Errorf("unsupported CUE declaration: %T", elt)
+		}
+	}
+	return nil
+}
+
+// adds the fields of the embedded struct or definition to the object
+func (g *generator) embed(o *object, expr ast.Expr) error {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return g.embed(o, e.X)
+	case *ast.StructLit:
+		return g.fields(o, e)
+	case *ast.Ident:
+		if name, ok := strings.CutPrefix(e.Name, "#"); ok {
+			if definition, ok := g.definitions[name]; ok {
+				return g.embed(o, definition)
+			}
+		}
+	}
+	return fmt.Errorf("unsupported CUE embedding: %T", expr)
+}
@@ -0,0 +1,149 @@
+package cue
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+func TestJSONSchema(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	schema, err := JSONSchema(model.CodeMeta30)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g.Ω(schema["$schema"]).Should(gomega.Equal(JSONSchemaDialect))
+	g.Ω(schema["$ref"]).Should(gomega.Equal("#/$defs/SoftwareSourceCode"))
+
+	defs := schema["$defs"].(map[string]any)
+	person := defs["Person"].(map[string]any)
+	g.Ω(person["additionalProperties"]).Should(gomega.Equal(false))
+	g.Ω(person["required"]).Should(gomega.Equal([]string{"@type"}))
+	properties := person["properties"].(map[string]any)
+	// the properties of the embedded #Thing
+	g.Ω(properties).Should(gomega.HaveKey("sameAs"))
+	g.Ω(properties["@type"]).Should(gomega.Equal(map[string]any{"const": "Person"}))
+
+	properties = defs["SoftwareSourceCode"].(map[string]any)["properties"].(map[string]any)
+	g.Ω(properties).Should(gomega.HaveKey("continuousIntegration"))
+	g.Ω(properties).ShouldNot(gomega.HaveKey("contIntegration"))
+	g.Ω(properties["dateCreated"]).Should(gomega.Equal(map[string]any{"$ref": "#/$defs/ValidDate"}))
+	g.Ω(defs["ValidDate"]).Should(gomega.Equal(map[string]any{"anyOf": []any{
+		map[string]any{"type": "string", "format": "date-time"},
+		map[string]any{"type": "string", "format": "date"},
+	}}))
+	g.Ω(defs["DevelopmentStatus"]).Should(gomega.HaveKeyWithValue("enum", gomega.ContainElements("Active", "active", "WIP", "wip")))
+	g.Ω(defs["ValidEmail"]).Should(gomega.HaveKey("pattern"))
+	g.Ω(defs["CreativeWork"].(map[string]any)["properties"].(map[string]any)["@type"]).Should(gomega.HaveKeyWithValue("default", "CreativeWork"))
+
+	schema, err = JSONSchema(model.CodeMeta20)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	properties = schema["$defs"].(map[string]any)["SoftwareSourceCode"].(map[string]any)["properties"].(map[string]any)
+	g.Ω(properties).Should(gomega.HaveKey("contIntegration"))
+	g.Ω(properties).ShouldNot(gomega.HaveKey("continuousIntegration"))
+	g.Ω(properties["@context"]).Should(gomega.Equal(map[string]any{"$ref": "#/$defs/Context"}))
+
+	_, err = JSONSchema("1.0")
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+// the schema checked in to the schema directory, which editors are pointed to, is the generated
+// schema of the default version, regenerate it with
+// "codemetagenerator schema export --format jsonschema -o schema/codemeta-<version>.schema.json"
+func TestJSONSchemaInSync(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	version := model.DefaultVersion
+	name := fmt.Sprintf("schema/codemeta-%s.schema.json", version)
+	bytes, err := os.ReadFile("../../" + name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	schema, err := JSONSchema(version)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// compared as written, so that the order of the keys is kept as well
+	g.Ω(string(bytes)).Should(gomega.Equal(utils.FormatJSON(schema)), "%s is out of date, regenerate it with: codemetagenerator schema export --format jsonschema -o %s", name, name)
+}
+
+// derives the definitions, the properties of #SoftwareSourceCode and the string constraints
+// from the compiled CUE schema and checks that they match the generated JSON Schema
+func TestJSONSchemaMatchesCUE(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for _, version := range model.Versions {
+		value, err := Schema(version)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		schema, err := JSONSchema(version)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defs := schema["$defs"].(map[string]any)
+
+		definitions := []string{}
+		iter, err := value.Fields(cue.Definitions(true))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for iter.Next() {
+			if iter.Selector().IsDefinition() {
+				definitions = append(definitions, iter.Selector().String()[1:])
+			}
+		}
+		g.Ω(defs).Should(gomega.HaveLen(len(definitions)), version)
+		for _, definition := range definitions {
+			g.Ω(defs).Should(gomega.HaveKey(definition), version)
+		}
+
+		document := defs["SoftwareSourceCode"].(map[string]any)
+		properties := document["properties"].(map[string]any)
+		required := []string{}
+		iter, err = value.LookupPath(cue.ParsePath("#SoftwareSourceCode")).Fields(cue.Optional(true))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		count := 0
+		for iter.Next() {
+			count++
+			name := iter.Selector().Unquoted()
+			g.Ω(properties).Should(gomega.HaveKey(name), version)
+			if !iter.IsOptional() {
+				required = append(required, name)
+			}
+		}
+		g.Ω(properties).Should(gomega.HaveLen(count), version)
+		g.Ω(document["required"]).Should(gomega.ConsistOf(required), version)
+
+		// the enum and patterns accept what the CUE schema accepts
+		accepts := func(definition string, s string) bool {
+			field := value.LookupPath(cue.ParsePath(definition))
+			return field.Unify(ctx.CompileString(fmt.Sprintf("%q", s))).Validate(cue.Concrete(true)) == nil
+		}
+		for _, status := range defs["DevelopmentStatus"].(map[string]any)["enum"].([]any) {
+			g.Expect(accepts("#DevelopmentStatus", status.(string))).To(gomega.BeTrue(), status)
+		}
+		g.Expect(accepts("#DevelopmentStatus", "retired")).To(gomega.BeFalse())
+		samples := map[string][]string{
+			"ValidURL":   {"https://example.org", "http://www.example.org/path?q=1", "example", "not a url", "ftp://example.org"},
+			"ValidEmail": {"jane@example.org", "jane.doe+tag@sub.example.co", "jane", "jane@example", "@example.org"},
+		}
+		for definition, values := range samples {
+			pattern := regexp.MustCompile(defs[definition].(map[string]any)["pattern"].(string))
+			for _, s := range values {
+				g.Ω(pattern.MatchString(s)).Should(gomega.Equal(accepts("#"+definition, s)), "%s: %s", definition, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cue
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/onsi/gomega"
+)
+
+// derives the person and organization valued properties from the schema and checks that
+// they match model.PartyProperties
+func TestPartyPropertiesMatchSchema(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	value, err := Schema(model.DefaultVersion)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	definition := value.LookupPath(cue.ParsePath("#SoftwareSourceCode"))
+
+	accepts := func(field cue.Value, json string) bool {
+		return field.Unify(ctx.CompileString(json)).Validate(cue.Concrete(true)) == nil
+	}
+	person := `{"@type": "Person", "givenName": "Jane"}`
+	organization := `{"@type": "Organization", "name": "Org"}`
+	// properties accepting any #Thing are not person or organization valued
+	thing := `{"@type": "Thing", "name": "Thing"}`
+
+	derived := []model.PartyProperty{}
+	iter, err := definition.Fields(cue.Optional(true))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for iter.Next() {
+		field := iter.Value()
+		property := model.PartyProperty{
+			Name:         iter.Selector().Unquoted(),
+			Person:       accepts(field, person),
+			Organization: accepts(field, organization),
+		}
+		if (!property.Person && !property.Organization) || accepts(field, thing) {
+			continue
+		}
+		if property.Person {
+			property.Multiple = accepts(field, "["+person+"]")
+		} else {
+			property.Multiple = accepts(field, "["+organization+"]")
+		}
+		derived = append(derived, property)
+	}
+	g.Ω(derived).Should(gomega.ConsistOf(model.PartyProperties))
+}
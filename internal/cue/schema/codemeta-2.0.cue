@@ -0,0 +1,46 @@
+// CodeMeta 2.0, see: https://doi.org/10.5063/schema/codemeta-2.0
+// CodeMeta 3.0 renamed contIntegration to continuousIntegration and embargoDate to
+// embargoEndDate, and added hasSourceCode, isSourceOf and Grant valued funding
+#Context: "https://doi.org/10.5063/schema/codemeta-2.0"
+
+#SoftwareSourceCode: {
+	"@context": #Context
+	#CreativeWork & {
+		"@type": "SoftwareSourceCode"
+	}
+	// schema.org terms
+	applicationCategory?: string | #ValidURL
+	applicationSubCategory?: string | #ValidURL
+	codeRepository?: #ValidURL
+	codeSampleType?: string
+	downloadUrl?: #ValidURL
+	fileFormat?: string | #ValidURL
+	fileSize?: string
+	installUrl?: #ValidURL
+	memoryRequirements?: string | #ValidURL
+	operatingSystem?: string
+	permissions?: string
+	processorRequirements?: string
+	programmingLanguage?: #ComputerLanguage | string | [...(#ComputerLanguage | string)]
+	relatedLink?: #ValidURL
+	releaseNotes?: string | #ValidURL
+	runtimePlatform?: string | [...string]
+	softwareHelp?: #CreativeWork | #SoftwareSourceCode
+	softwareRequirements?: #SoftwareApplication | #SoftwareSourceCode | [...(#SoftwareApplication | #SoftwareSourceCode)]
+	storageRequirements?: string | #ValidURL
+	supportingData?: #Thing
+	targetProduct?: #SoftwareApplication
+	// codemeta terms
+	buildInstructions?: #ValidURL
+	contIntegration?: #ValidURL | [...#ValidURL]
+	developmentStatus?: #DevelopmentStatus
+	embargoDate?: #ValidDate
+	funding?: string | [...string]
+	issueTracker?: #ValidURL
+	maintainer?: (#Organization | #Person) | [...(#Organization | #Person)]
+	readme?: #ValidURL
+	referencePublication?: string
+	softwareSuggestions?: string | [...(#SoftwareApplication | #SoftwareSourceCode)]
+}
+
+{#SoftwareSourceCode}
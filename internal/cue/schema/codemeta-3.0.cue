@@ -0,0 +1,55 @@
+// CodeMeta 3.0, see: https://w3id.org/codemeta/3.0
+// the "master" contexts refer to the latest version of CodeMeta
+#Context: "https://w3id.org/codemeta/3.0" | "https://raw.githubusercontent.com/codemeta/codemeta/master/codemeta.json" | "https://raw.githubusercontent.com/codemeta/codemeta/master/codemeta.jsonld"
+
+#Grant: {
+	#Thing & {
+		"@type": "Grant"
+	}
+	funder?: #Organization | #Person | [...(#Organization | #Person)]
+	sponsor?: #Organization | #Person | [...(#Organization | #Person)]
+}
+
+#SoftwareSourceCode: {
+	"@context": #Context
+	#CreativeWork & {
+		"@type": "SoftwareSourceCode"
+	}
+	// schema.org terms
+	applicationCategory?: string | #ValidURL
+	applicationSubCategory?: string | #ValidURL
+	codeRepository?: #ValidURL
+	codeSampleType?: string
+	downloadUrl?: #ValidURL
+	fileFormat?: string | #ValidURL
+	fileSize?: string
+	installUrl?: #ValidURL
+	memoryRequirements?: string | #ValidURL
+	operatingSystem?: string
+	permissions?: string
+	processorRequirements?: string
+	programmingLanguage?: #ComputerLanguage | string | [...(#ComputerLanguage | string)]
+	relatedLink?: #ValidURL
+	releaseNotes?: string | #ValidURL
+	runtimePlatform?: string | [...string]
+	softwareHelp?: #CreativeWork | #SoftwareSourceCode
+	softwareRequirements?: #SoftwareApplication | #SoftwareSourceCode | [...(#SoftwareApplication | #SoftwareSourceCode)]
+	storageRequirements?: string | #ValidURL
+	supportingData?: #Thing
+	targetProduct?: #SoftwareApplication
+	// codemeta terms
+	buildInstructions?: #ValidURL
+	continuousIntegration?: #ValidURL | [...#ValidURL]
+	developmentStatus?: #DevelopmentStatus
+	embargoEndDate?: #ValidDate
+	funding?: string | #Grant | [...(string | #Grant)]
+	hasSourceCode?: #SoftwareSourceCode
+	isSourceOf?: #SoftwareSourceCode
+	issueTracker?: #ValidURL
+	maintainer?: (#Organization | #Person) | [...(#Organization | #Person)]
+	readme?: #ValidURL
+	referencePublication?: string
+	softwareSuggestions?: string | [...(#SoftwareApplication | #SoftwareSourceCode)]
+}
+
+{#SoftwareSourceCode}
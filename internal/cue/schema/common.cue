@@ -0,0 +1,223 @@
+// the schema.org types shared by the CodeMeta versions, see: https://schema.org
+import "time"
+
+// see: https://www.repostatus.org/
+#DevelopmentStatus: =~"(?i)^Abandoned$" | =~"(?i)^Active$" | =~"(?i)^Concept$" | =~"(?i)^Inactive$" | =~"(?i)^Moved$" | =~"(?i)^Suspended$" | =~"(?i)^Unsupported$" | =~"(?i)^WIP$"
+
+#ValidEmail: =~ "^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}$"
+#ValidURL: =~ "^(http:\/\/www\\.|https:\/\/www\\.|http:\/\/|https:\/\/|\/|\/\/)?[A-z0-9_-]*?[:]?[A-z0-9_-]*?[@]?[A-z0-9]+([\\-\\.]{1}[a-z0-9]+)*\\.[a-z]{2,5}(:[0-9]{1,5})?(\/.*)?$"
+// see: https://ijmacd.github.io/rfc3339-iso8601/
+#ValidDate: time.Format(time.RFC3339) | time.Format("2006-01-02")
+#ValidTime: time.Format(time.Kitchen24) | time.Format("15:04:05Z") | time.Format("15:04:05-07:00") | time.Format("15:04:05+07:00")
+
+#Thing: {
+    "@type": string
+	"@id"?: string
+	additionalType?: string | #ValidURL
+	alternateName?: string
+	description?: string | #Thing
+	disambiguatingDescription?: string
+	identifier?:  #Thing | string | #ValidURL
+	image?: #Thing | #ValidURL
+	mainEntityOfPage?: #CreativeWork | #ValidURL
+	name?: string
+	potentialAction?: #Thing
+	sameAs?: #ValidURL
+	subjectOf?: #CreativeWork | #Thing
+	url?: #ValidURL
+}
+
+#Person: {
+	#Thing & {
+		"@type": "Person"
+	}
+	affiliation?: string | #Organization
+	description?: string
+	email?: #ValidEmail
+	familyName?: string
+	givenName?: string
+}
+
+#Organization: {
+	#Thing & {
+		"@type": "Organization"
+	}
+	address?: string
+	description?: string
+	email?: #ValidEmail
+}
+
+#ComputerLanguage: {
+	#Thing & {
+		"@type": "ComputerLanguage"
+	}
+	version?: string
+}
+
+#ListItem: {
+	#Thing & {
+		"@type": "ListItem"
+	}
+	item?: string | #Thing
+	nextItem?: string | #ListItem
+	position?: int | string
+	previousItem?: string | #ListItem
+}
+
+#ItemList: {
+	#Thing & {
+		"@type": "ItemList"
+	}
+	itemListElement?: string | #Thing | #ListItem | [...(string | #Thing | #ListItem)]
+	itemListOrder?: string
+	numberOfItems?: int
+}
+
+#AggregateRating: {
+	#Thing & {
+		"@type": "AggregateRating"
+	}
+	itemReviewed?: string | #Thing
+	ratingCount?: int
+	reviewCount?: int
+}
+
+#DefinedTermSet: {
+	#Thing & {
+		"@type": "DefinedTermSet"
+	}
+	hasDefinedTerm?: string | #DefinedTerm | [...(string | #DefinedTerm)]
+}
+
+#DefinedTerm: {
+	#Thing & {
+		"@type": "DefinedTerm"
+	}
+	inDefinedTermSet?: string | #DefinedTermSet
+	termCode?: string
+}
+
+#CreativeWork: {
+	#Thing & {
+		"@type": string | *"CreativeWork"
+	}
+	about?: #Thing
+	abstract?: string
+	accessMode?: string
+	accessModeSufficient?: #ItemList
+	accessibilityAPI?: string
+	accessibilityControl?: string
+	accessibilityFeature?: string
+	accessibilityHazard?: string
+	accessibilitySummary?: string
+	accountablePerson?: #Person | [...#Person]
+	acquireLicensePage?: #CreativeWork | #ValidURL
+	aggregateRating?: #AggregateRating
+	alternativeHeadline?: string
+	archivedAt?: #ValidURL | #Thing
+	assesses?: #DefinedTerm | string
+	associatedMedia?: #Thing
+	audience?: #Thing
+	audio?: #Thing
+	author?: #Organization | #Person | [...(#Organization | #Person)]
+	award?: string
+	character?: #Person
+	citation?: #CreativeWork | string
+	comment?: #Thing
+	commentCount?: int
+	conditionsOfAccess?: string
+	contentLocation?: #Thing
+	contentRating?: #Thing | string
+	contentReferenceTime?: #ValidDate
+	contributor?: #Organization | #Person | [...(#Organization | #Person)]
+	copyrightHolder?: #Organization | #Person | [...(#Organization | #Person)]
+	copyrightNotice?: string
+	copyrightYear?: int | float
+	correction?: #Thing | string | #ValidURL
+	countryOfOrigin?: #Thing
+	creativeWorkStatus?: #DefinedTerm | string
+	creator?: #Organization | #Person | [...(#Organization | #Person)]
+	creditText?: string
+	dateCreated?: #ValidDate
+	dateModified?: #ValidDate
+	datePublished?: #ValidDate
+	digitalSourceType?: #Thing
+	discussionUrl?: #ValidURL
+	editEIDR?: #ValidURL | string
+	editor?: #Person | [...#Person]
+	educationalAlignment?: #Thing
+	educationalLevel?: #DefinedTerm | string | #ValidURL
+	educationalUse?: #DefinedTerm | string
+	encoding?: #Thing
+	encodingFormat?: #ValidURL | string
+	exampleOfWork?: #CreativeWork
+	expires?: #ValidDate
+	funder?: #Organization | #Person | [...(#Organization | #Person)]
+	// an open Grant keeps the recursive creative works cheap to evaluate, #SoftwareSourceCode
+	// validates its funding as a #Grant
+	funding?: string | #Thing | {"@type": "Grant", ...} | [...(string | #Thing | {"@type": "Grant", ...})]
+	genre?: string | #ValidURL
+	hasPart?: #CreativeWork
+	headline?: string
+	inLanguage?: #Thing | string | [...(#Thing | string)]
+	interactionStatistic?: #Thing
+	interactivityType?: string
+	interpretedAsClaim?: #Thing
+	isAccessibleForFree?: bool
+	isBasedOn?: #CreativeWork | #Thing | #ValidURL
+	isFamilyFriendly?: bool
+	isPartOf?:  #CreativeWork | #ValidURL
+	keywords?: #DefinedTerm | string | #ValidURL | [...(#DefinedTerm | string | #ValidURL)]
+	learningResourceType?: #DefinedTerm | string
+	license?: #CreativeWork | #ValidURL
+	locationCreated?: #Thing
+	mainEntity?: #Thing
+	maintainer?: #Person | #Organization | [...(#Person | #Organization)]
+	material?: #Thing | string | #ValidURL
+	materialExtent?: string
+	mentions?: #Thing
+	offers?: #Thing
+	pattern?: #DefinedTerm | string
+	position?: int | string
+	producer?: #Organization | #Person | [...(#Organization | #Person)]
+	provider?: #Organization | #Person | [...(#Organization | #Person)]
+	publication?: #Thing
+	publisher?: #Organization | #Person | [...(#Organization | #Person)]
+	publisherImprint?: #Organization
+	publishingPrinciples?: #CreativeWork | #ValidURL
+	recordedAt?: #Thing
+	releasedEvent?: #Thing
+	review?: #Thing
+	schemaVersion?: string | #ValidURL
+	sdDatePublished?: #ValidDate
+	sdLicense?: #CreativeWork | #ValidURL
+	sdPublisher?: #Organization | #Person
+	size?: #DefinedTerm | #Thing | string
+	sourceOrganization?: #Organization
+	spatial?: #Thing
+	spatialCoverage?: #Thing
+	sponsor?: #Organization | #Person | [...(#Organization | #Person)]
+	teaches?: #DefinedTerm | string
+	temporal?: #ValidDate | string
+	temporalCoverage?: #ValidDate | string | #ValidURL
+	text?: string
+	thumbnail?: #Thing
+	thumbnailUrl?: #ValidURL
+	timeRequired?: #Thing
+	translatonOfWork?: #CreativeWork
+	translator?: #Organization | #Person | [...(#Organization | #Person)]
+	typicalAgeRange?: string
+	usageInfo?: #CreativeWork | #ValidURL
+	version?: int | float | string
+	video?: #Thing
+	workExample?: #CreativeWork
+	workTranslation?: #CreativeWork
+}
+
+#SoftwareApplication: {
+	#CreativeWork & {
+		"@type": "SoftwareApplication"
+	}
+	name: string
+	provider?: (#Organization | #Person) | [...(#Organization | #Person)]
+}
@@ -1,288 +1,146 @@
 package cue
 
 import (
+	"embed"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
 	"cuelang.org/go/cue/errors"
 	cuetoken "cuelang.org/go/cue/token"
-	"cuelang.org/go/encoding/json"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/samber/lo"
 )
 
-// see: https://ijmacd.github.io/rfc3339-iso8601/
-const (
-	schema = `
-import "time"
+// the schema of each CodeMeta version, schema/codemeta-<version>.cue, shares the types of
+// schema/common.cue
+//
+//go:embed schema/*.cue
+var schemaFiles embed.FS
 
-#Context: "https://w3id.org/codemeta/3.0" | "https://raw.githubusercontent.com/codemeta/codemeta/master/codemeta.json" | "https://doi.org/10.5063/schema/codemeta-2.0" | "https://raw.githubusercontent.com/codemeta/codemeta/master/codemeta.jsonld"
-#DevelopmentStatus: =~ {"(?i)^Abandoned$" | "(?i)^Active$" | "(?i)^Concept$" | "(?i)^Inactive$" | "(?i)^Moved$" | "(?i)^Suspended$" | "(?i)^Unsupported$" | "(?i)^WIP$"}
-
-#ValidEmail: =~ "^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}$"
-#ValidURL: =~ "^(http:\/\/www\\.|https:\/\/www\\.|http:\/\/|https:\/\/|\/|\/\/)?[A-z0-9_-]*?[:]?[A-z0-9_-]*?[@]?[A-z0-9]+([\\-\\.]{1}[a-z0-9]+)*\\.[a-z]{2,5}(:[0-9]{1,5})?(\/.*)?$"
-#ValidDate: time.Format(time.RFC3339) | time.Format("2006-01-02")
-#ValidTime: time.Format(time.Kitchen24) | time.Format("15:04:05Z") | time.Format("15:04:05-07:00") | time.Format("15:04:05+07:00")
+var ctx *cue.Context = cuecontext.New()
 
-#Thing: {
-    "@type": string
-	"@id"?: string
-	additionalType?: string | #ValidURL
-	alternateName?: string
-	description?: string | #Thing
-	disambiguatingDescription?: string
-	identifier?:  #Thing | string | #ValidURL
-	image?: #Thing | #ValidURL
-	mainEntityOfPage?: #CreativeWork | #ValidURL
-	name?: string
-	potentialAction?: #Thing
-	sameAs?: #ValidURL
-	subjectOf?: #CreativeWork | #Thing
-	url?: #ValidURL
-}
+type compiled struct {
+	once  sync.Once
+	value cue.Value
+	err   error
+}
+
+var (
+	// the schema of each version is compiled once and reused
+	compiledSchemas = lo.SliceToMap(model.Versions, func(version string) (string, *compiled) {
+		return version, &compiled{}
+	})
+	// a cue.Context is not safe for concurrent use and values of different contexts cannot
+	// be unified, so every use of the shared context and the schemas compiled with it is
+	// serialized
+	mu sync.Mutex
+)
 
-#Person: {
-	#Thing & {
-		"@type": "Person"
+func validVersion(version string) error {
+	if !lo.Contains(model.Versions, version) {
+		return fmt.Errorf("unsupported CodeMeta version: %s, must be one of: %s", version, strings.Join(model.Versions, ", "))
 	}
-	affiliation?: string | #Organization
-	description?: string
-	email?: #ValidEmail
-	familyName?: string
-	givenName?: string
+	return nil
 }
 
-#Organization: {
-	#Thing & {
-		"@type": "Organization"
+// returns the effective CUE schema of the CodeMeta version, the shared types followed by
+// the types of the version
+func Source(version string) (string, error) {
+	if err := validVersion(version); err != nil {
+		return "", err
 	}
-	address?: string
-	description?: string
-	email?: #ValidEmail
-}
-
-#ComputerLanguage: {
-	#Thing & {
-		"@type": "ComputerLanguage"
+	common, err := schemaFiles.ReadFile("schema/common.cue")
+	if err != nil {
+		return "", err
 	}
-	version?: string
-}
-
-#ListItem: {
-	#Thing & {
-		"@type": "ListItem"
+	versioned, err := schemaFiles.ReadFile("schema/codemeta-" + version + ".cue")
+	if err != nil {
+		return "", err
 	}
-	item?: string | #Thing
-	nextItem?: string | #ListItem
-	position?: int | string
-	previousItem?: string | #ListItem
+	return string(common) + "\n" + string(versioned), nil
 }
 
-#ItemList: {
-	#Thing & {
-		"@type": "ItemList"
+// returns the compiled schema of the version, the lock must be held
+func schemaOf(version string) (cue.Value, error) {
+	if err := validVersion(version); err != nil {
+		return cue.Value{}, err
 	}
-	itemListElement?: string | #Thing | #ListItem | [...(string | #Thing | #ListItem)]
-	itemListOrder?: string
-	numberOfItems?: int
+	c := compiledSchemas[version]
+	c.once.Do(func() {
+		source, err := Source(version)
+		if err != nil {
+			c.err = err
+			return
+		}
+		c.value = ctx.CompileString(source, cue.Filename("codemeta-"+version+".cue"))
+		// ensure schema is valid cue
+		if c.value.Err() != nil {
+			c.err = fmt.Errorf(errors.Details(c.value.Err(), nil))
+		}
+	})
+	return c.value, c.err
 }
 
-#AggregateRating: {
-	#Thing & {
-		"@type": "AggregateRating"
-	}
-	itemReviewed?: string | #Thing
-	ratingCount?: int
-	reviewCount?: int
+// returns the schema of the CodeMeta version, which is compiled once and reused
+func Schema(version string) (cue.Value, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return schemaOf(version)
 }
 
-#DefinedTermSet: {
-	#Thing & {
-		"@type": "DefinedTermSet"
+// returns the CodeMeta version of the document by its "@context", which may be a list of
+// contexts. Documents without a known context are of the default version.
+func DetectVersion(v []byte) string {
+	var document struct {
+		Context any `json:"@context"`
 	}
-	hasDefinedTerm?: string | #DefinedTerm | [...(string | #DefinedTerm)]
-}
-
-#DefinedTerm: {
-	#Thing & {
-		"@type": "DefinedTerm"
+	if json.Unmarshal(v, &document) == nil {
+		contexts := []any{document.Context}
+		if list, ok := document.Context.([]any); ok {
+			contexts = list
+		}
+		for _, context := range contexts {
+			if s, ok := context.(string); ok {
+				if version, ok := model.VersionOf(s); ok {
+					return version
+				}
+			}
+		}
 	}
-	inDefinedTermSet?: string | #DefinedTermSet
-	termCode?: string
+	return model.DefaultVersion
 }
 
-#CreativeWork: {
-	#Thing & {
-		"@type": string | *"CreativeWork"
+// unifies the schema of the CodeMeta version with the JSON document and returns the
+// validation errors, if any. An empty version is detected from the document. Positions
+// of the errors in the document refer to the filename.
+func Check(v []byte, filename string, version string) ([]errors.Error, error) {
+	if version == "" {
+		version = DetectVersion(v)
 	}
-	about?: #Thing
-	abstract?: string
-	accessMode?: string
-	accessModeSufficient?: #ItemList
-	accessibilityAPI?: string
-	accessibilityControl?: string
-	accessibilityFeature?: string
-	accessibilityHazard?: string
-	accessibilitySummary?: string
-	accountablePerson?: #Person | [...#Person]
-	acquireLicensePage?: #CreativeWork | #ValidURL
-	aggregateRating?: #AggregateRating
-	alternativeHeadline?: string
-	archivedAt?: #ValidURL | #Thing
-	assesses?: #DefinedTerm | string
-	associatedMedia?: #Thing
-	audience?: #Thing
-	audio?: #Thing
-	author?: #Organization | #Person | [...(#Organization | #Person)]
-	award?: string
-	character?: #Person
-	citation?: #CreativeWork | string
-	comment?: #Thing
-	commentCount?: int
-	conditionsOfAccess?: string
-	contentLocation?: #Thing
-	contentRating?: #Thing | string
-	contentReferenceTime?: #ValidDate
-	contributor?: #Organization | #Person | [...(#Organization | #Person)]
-	copyrightHolder?: #Organization | #Person | [...(#Organization | #Person)]
-	copyrightNotice?: string
-	copyrightYear?: int | float
-	correction?: #Thing | string | #ValidURL
-	countryOfOrigin?: #Thing
-	creativeWorkStatus?: #DefinedTerm | string
-	creator?: #Organization | #Person | [...(#Organization | #Person)]
-	creditText?: string
-	dateCreated?: #ValidDate
-	dateModified?: #ValidDate
-	datePublished?: #ValidDate
-	digitalSourceType?: #Thing
-	discussionUrl?: #ValidURL
-	editEIDR?: #ValidURL | string
-	editor?: #Person | [...#Person]
-	educationalAlignment?: #Thing
-	educationalLevel?: #DefinedTerm | string | #ValidURL
-	educationalUse?: #DefinedTerm | string
-	encoding?: #Thing
-	encodingFormat?: #ValidURL | string
-	exampleOfWork?: #CreativeWork
-	expires?: #ValidDate
-	funder?: #Organization | #Person | [...(#Organization | #Person)]
-	funding?: string | #Thing
-	genre?: string | #ValidURL
-	hasPart?: #CreativeWork
-	headline?: string
-	inLanguage?: #Thing | string | [...(#Thing | string)]
-	interactionStatistic?: #Thing
-	interactivityType?: string
-	interpretedAsClaim?: #Thing
-	isAccessibleForFree?: bool
-	isBasedOn?: #CreativeWork | #Thing | #ValidURL
-	isFamilyFriendly?: bool
-	isPartOf?:  #CreativeWork | #ValidURL
-	keywords?: #DefinedTerm | string | #ValidURL | [...(#DefinedTerm | string | #ValidURL)]
-	learningResourceType?: #DefinedTerm | string
-	license?: #CreativeWork | #ValidURL
-	locationCreated?: #Thing
-	mainEntity?: #Thing
-	maintainer?: #Person | #Organization | [...(#Person | #Organization)]
-	material?: #Thing | string | #ValidURL
-	materialExtent?: string
-	mentions?: #Thing
-	offers?: #Thing
-	pattern?: #DefinedTerm | string
-	position?: int | string
-	producer?: #Organization | #Person | [...(#Organization | #Person)]
-	provider?: #Organization | #Person | [...(#Organization | #Person)]
-	publication?: #Thing
-	publisher?: #Organization | #Person | [...(#Organization | #Person)]
-	publisherInprint?: #Organization
-	publishingPrinciples?: #CreativeWork | #ValidURL
-	recordedAt?: #Thing
-	releasedEvent?: #Thing
-	review?: #Thing
-	schemaVersion?: string | #ValidURL
-	sdDatePublished?: #ValidDate
-	sdLicense?: #CreativeWork | #ValidURL
-	sdPublisher?: #Organization | #Person
-	size?: #DefinedTerm | #Thing | string
-	sourceOrganization?: #Organization
-	spatial?: #Thing
-	spatialCoverage?: #Thing
-	sponsor?: #Organization | #Person | [...(#Organization | #Person)]
-	teaches?: #DefinedTerm | string
-	temporal?: #ValidDate | string
-	temporalCoverage?: #ValidDate | string | #ValidURL
-	text?: string
-	thumbnail?: #Thing
-	thumbnailUrl?: #ValidURL
-	timeRequired?: #Thing
-	translatonOfWork?: #CreativeWork
-	translator?: #Organization | #Person | [...(#Organization | #Person)]
-	typicalAgeRange?: string
-	usageInfo?: #CreativeWork | #ValidURL
-	version?: int | float | string
-	video?: #Thing
-	workExample?: #CreativeWork
-	workTranslation?: #CreativeWork
-}
-
-#SoftwareApplication: {
-	#CreativeWork & {
-		"@type": "SoftwareApplication"
+	mu.Lock()
+	defer mu.Unlock()
+	schema, err := schemaOf(version)
+	if err != nil {
+		return nil, err
 	}
-	name: string
-	provider?: (#Organization | #Person) | [...(#Organization | #Person)]
-}
-
-#SoftwareSourceCode: {
-	"@context": #Context
-	#CreativeWork & {
-		"@type": "SoftwareSourceCode"
+	document := ctx.CompileBytes(v, cue.Filename(filename))
+	if document.Err() != nil {
+		return errors.Errors(document.Err()), nil
+	}
+	err = schema.Unify(document).Validate(cue.Final())
+	if err == nil {
+		return nil, nil
 	}
-	// schema.org terms
-	applicationCategory?: string | #ValidURL
-	applicationSubCategory?: string | #ValidURL
-	codeRepository?: #ValidURL
-	codeSampleType?: string
-	downloadUrl?: #ValidURL
-	fileFormat?: string | #ValidURL
-	fileSize?: string
-	installUrl?: #ValidURL
-	memoryRequirements?: string | #ValidURL
-	operatingSystem?: string
-	permissions?: string
-	processorRequirements?: string
-	programmingLanguage?: #ComputerLanguage | string
-	relatedLink?: #ValidURL
-	releaseNotes?: string | #ValidURL
-	runtimePlatform?: string
-	softwareHelp?: #CreativeWork | #SoftwareSourceCode
-	softwareRequirements?: #SoftwareApplication | #SoftwareSourceCode | [...(#SoftwareApplication | #SoftwareSourceCode)]
-	storageRequirements?: string | #ValidURL
-	supportingData?: #Thing
-	targetProduct?: #SoftwareApplication
-	// codemeta terms
-	buildInstructions?: #ValidURL
-	continuousIntegration?: #ValidURL | [...#ValidURL]
-	developmentStatus?: #DevelopmentStatus
-	embargoEndDate?: #ValidDate
-	funding?: string
-	hasSourceCode?: #SoftwareSourceCode
-	isSourceOf?: #SoftwareSourceCode
-	issueTracker?: #ValidURL
-	maintainer?: (#Organization | #Person) | [...(#Organization | #Person)]
-	readme?: #ValidURL 
-	referencePublication?: string
-	softwareSuggestions?: string | [...(#SoftwareApplication | #SoftwareSourceCode)]
+	var l list = errors.Errors(err)
+	l.dedupe()
+	return l, nil
 }
 
-{#SoftwareSourceCode}
-`
-)
-
-var ctx *cue.Context = cuecontext.New()
-
 type list []errors.Error
 
 type sortable struct {
@@ -343,19 +201,23 @@ func equalPath(a, b []string) bool {
 	return true
 }
 
+// validates the document against the schema of the CodeMeta version of its "@context"
 func Validate(v []byte) error {
-	schema := ctx.CompileString(schema, cue.Filename("codemeta.cue"))
-	// ensure schema is valid cue
-	if schema.Err() != nil {
-		msg := errors.Details(schema.Err(), nil)
-		return fmt.Errorf(msg)
-	}
+	return ValidateVersion(v, "")
+}
 
-	var b strings.Builder
-	err := json.Validate(v, schema)
+// validates the document against the schema of the CodeMeta version, an empty version is
+// detected from the document
+func ValidateVersion(v []byte, version string) error {
+	if !json.Valid(v) {
+		return fmt.Errorf("json: invalid JSON")
+	}
+	l, err := Check(v, "json.Validate", version)
 	if err != nil {
-		var l list = errors.Errors(err)
-		l.dedupe()
+		return err
+	}
+	if len(l) > 0 {
+		var b strings.Builder
 		for _, e := range l {
 			fmt.Fprintf(&b, "%s\n", e.Error())
 		}
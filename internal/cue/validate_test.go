@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/cacoco/codemetagenerator/internal/model"
 	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
 )
 
 func TestValidate(t *testing.T) {
@@ -216,3 +218,107 @@ func TestEqualPath(t *testing.T) {
 		t.Errorf("Expected false")
 	}
 }
+
+func TestValidateFunding(t *testing.T) {
+	bytes := []byte(`
+{
+	"@context": "https://w3id.org/codemeta/3.0",
+	"@type": "SoftwareSourceCode",
+	"funding": [
+		"Legacy funding text",
+		{
+			"@type": "Grant",
+			"identifier": "1234567",
+			"name": "Research Software Sustainability",
+			"funder": {
+				"@type": "Organization",
+				"@id": "https://ror.org/021nxhr62",
+				"name": "National Science Foundation"
+			}
+		}
+	]
+}`)
+
+	err := Validate(bytes)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestValidateFundingInvalidFunder(t *testing.T) {
+	bytes := []byte(`
+{
+	"@context": "https://w3id.org/codemeta/3.0",
+	"@type": "SoftwareSourceCode",
+	"funding": {
+		"@type": "Grant",
+		"identifier": "1234567",
+		"funder": {
+			"@type": "Organization",
+			"name": "National Science Foundation",
+			"email": "not an email"
+		}
+	}
+}`)
+
+	err := Validate(bytes)
+	if err == nil {
+		t.Errorf("Expected error")
+	}
+}
+
+// each version's schema accepts exactly the contexts of the version
+func TestSchemaContexts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for _, version := range model.Versions {
+		for _, context := range model.Contexts {
+			bytes := []byte(fmt.Sprintf(`{"@context": %q, "@type": "SoftwareSourceCode"}`, context))
+			expected, _ := model.VersionOf(context)
+			err := ValidateVersion(bytes, version)
+			if expected == version {
+				g.Expect(err).To(gomega.BeNil(), context)
+			} else {
+				g.Expect(err).ToNot(gomega.BeNil(), context)
+			}
+		}
+	}
+}
+
+func TestDetectVersion(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Ω(DetectVersion([]byte(`{"@context": "https://doi.org/10.5063/schema/codemeta-2.0"}`))).Should(gomega.Equal(model.CodeMeta20))
+	g.Ω(DetectVersion([]byte(`{"@context": ["https://example.org", "https://doi.org/10.5063/schema/codemeta-2.0"]}`))).Should(gomega.Equal(model.CodeMeta20))
+	g.Ω(DetectVersion([]byte(`{"@context": "https://w3id.org/codemeta/3.0"}`))).Should(gomega.Equal(model.CodeMeta30))
+	g.Ω(DetectVersion([]byte(`{"@context": "https://example.org"}`))).Should(gomega.Equal(model.DefaultVersion))
+	g.Ω(DetectVersion([]byte(`not json`))).Should(gomega.Equal(model.DefaultVersion))
+}
+
+func TestValidateVersionOnlyProperties(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	v2 := `{"@context": "https://doi.org/10.5063/schema/codemeta-2.0", "@type": "SoftwareSourceCode", %q: "https://ci.example.org"}`
+	v3 := `{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", %q: "https://ci.example.org"}`
+	g.Expect(Validate([]byte(fmt.Sprintf(v2, "contIntegration")))).To(gomega.BeNil())
+	g.Expect(Validate([]byte(fmt.Sprintf(v2, "continuousIntegration")))).ToNot(gomega.BeNil())
+	g.Expect(Validate([]byte(fmt.Sprintf(v3, "continuousIntegration")))).To(gomega.BeNil())
+	g.Expect(Validate([]byte(fmt.Sprintf(v3, "contIntegration")))).ToNot(gomega.BeNil())
+
+	// funding is a plain string in CodeMeta 2.0
+	grant := `{"@context": %q, "@type": "SoftwareSourceCode", "funding": {"@type": "Grant", "name": "A grant"}}`
+	g.Expect(Validate([]byte(fmt.Sprintf(grant, "https://doi.org/10.5063/schema/codemeta-2.0")))).ToNot(gomega.BeNil())
+	g.Expect(Validate([]byte(fmt.Sprintf(grant, "https://w3id.org/codemeta/3.0")))).To(gomega.BeNil())
+}
+
+func TestSchemaUnsupportedVersion(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	_, err := Source("1.0")
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(ValidateVersion([]byte(`{}`), "1.0")).ToNot(gomega.BeNil())
+	for _, version := range model.Versions {
+		_, err := Schema(version)
+		g.Expect(err).To(gomega.BeNil())
+	}
+}
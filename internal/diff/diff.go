@@ -0,0 +1,203 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/merge"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/patch"
+	"github.com/ohler55/ojg/oj"
+)
+
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change is a single difference between two JSON documents. The path uses the same
+// dot-separated syntax as the "set" and "delete" commands.
+type Change struct {
+	Kind string
+	Path string
+	Old  any
+	New  any
+}
+
+// returns the differences between the two JSON values, ordered by path
+func Diff(a, b any) []Change {
+	changes := []Change{}
+	compare("", a, b, &changes)
+	return changes
+}
+
+func join(path string, key string) string {
+	// escape the characters which are special in the path syntax
+	key = strings.NewReplacer(".", "\\.", "*", "\\*", "?", "\\?").Replace(key)
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func compare(path string, a, b any, changes *[]Change) {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(x)+len(y))
+		for key := range x {
+			keys = append(keys, key)
+		}
+		for key := range y {
+			if _, ok := x[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			left, inLeft := x[key]
+			right, inRight := y[key]
+			switch {
+			case !inRight:
+				*changes = append(*changes, Change{Kind: Removed, Path: join(path, key), Old: left})
+			case !inLeft:
+				*changes = append(*changes, Change{Kind: Added, Path: join(path, key), New: right})
+			default:
+				compare(join(path, key), left, right, changes)
+			}
+		}
+		return
+	case []any:
+		y, ok := b.([]any)
+		if !ok {
+			break
+		}
+		if isParties(x) && isParties(y) {
+			compareParties(path, x, y, changes)
+			return
+		}
+		for i := 0; i < len(x) || i < len(y); i++ {
+			elemPath := join(path, strconv.Itoa(i))
+			switch {
+			case i >= len(y):
+				*changes = append(*changes, Change{Kind: Removed, Path: elemPath, Old: x[i]})
+			case i >= len(x):
+				*changes = append(*changes, Change{Kind: Added, Path: elemPath, New: y[i]})
+			default:
+				compare(elemPath, x[i], y[i], changes)
+			}
+		}
+		return
+	}
+	if !patch.Equal(a, b) {
+		*changes = append(*changes, Change{Kind: Changed, Path: path, Old: a, New: b})
+	}
+}
+
+func isParties(arr []any) bool {
+	if len(arr) == 0 {
+		return false
+	}
+	for _, elem := range arr {
+		m, ok := elem.(map[string]any)
+		if !ok || (m[model.Type] != model.PersonType && m[model.Type] != model.OrganizationType) {
+			return false
+		}
+	}
+	return true
+}
+
+// persons and organizations are matched by identity rather than position, so that
+// re-ordering a list of authors is not reported as a change
+func compareParties(path string, a []any, b []any, changes *[]Change) {
+	matched := make([]bool, len(b))
+	for i, left := range a {
+		found := -1
+		for j, right := range b {
+			if !matched[j] && merge.SameParty(left, right) {
+				found = j
+				break
+			}
+		}
+		if found < 0 {
+			*changes = append(*changes, Change{Kind: Removed, Path: join(path, strconv.Itoa(i)), Old: left})
+			continue
+		}
+		matched[found] = true
+		compare(join(path, strconv.Itoa(found)), left, b[found], changes)
+	}
+	for j, right := range b {
+		if !matched[j] {
+			*changes = append(*changes, Change{Kind: Added, Path: join(path, strconv.Itoa(j)), New: right})
+		}
+	}
+}
+
+func toJSON(value any) string {
+	return oj.JSON(value, &oj.Options{Sort: true})
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s: %s", c.Path, toJSON(c.New))
+	case Removed:
+		return fmt.Sprintf("- %s: %s", c.Path, toJSON(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s => %s", c.Path, toJSON(c.Old), toJSON(c.New))
+	}
+}
+
+const (
+	green  = "\033[32m"
+	red    = "\033[31m"
+	yellow = "\033[33m"
+	reset  = "\033[0m"
+)
+
+// formats the changes one per line
+func Format(changes []Change) string {
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formats the changes one per line, colored for display in a terminal
+func FormatColor(changes []Change) string {
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		color := yellow
+		switch change.Kind {
+		case Added:
+			color = green
+		case Removed:
+			color = red
+		}
+		lines[i] = color + change.String() + reset
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formats the changes as a JSON array of {"kind", "path", "old", "new"} objects
+func FormatJSON(changes []Change) string {
+	arr := make([]any, len(changes))
+	for i, change := range changes {
+		m := map[string]any{"kind": change.Kind, "path": change.Path}
+		if change.Kind != Added {
+			m["old"] = change.Old
+		}
+		if change.Kind != Removed {
+			m["new"] = change.New
+		}
+		arr[i] = m
+	}
+	return oj.JSON(arr, &oj.Options{Sort: true, Indent: 2})
+}
@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/oj"
+	"github.com/onsi/gomega"
+)
+
+func parseJSON(s string) any {
+	var v any
+	oj.Unmarshal([]byte(s), &v)
+	return v
+}
+
+func TestDiff(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	a := parseJSON(`{"name": "a", "version": "1.0", "keywords": ["go"], "author": {"givenName": "Alice"}, "key.with.dots": 1}`)
+	b := parseJSON(`{"name": "b", "keywords": ["go", "cli"], "author": {"givenName": "Alice"}, "license": "https://spdx.org/licenses/MIT.html", "key.with.dots": 1}`)
+
+	changes := Diff(a, b)
+	g.Ω(changes).Should(gomega.Equal([]Change{
+		{Kind: Added, Path: "keywords.1", New: "cli"},
+		{Kind: Added, Path: "license", New: "https://spdx.org/licenses/MIT.html"},
+		{Kind: Changed, Path: "name", Old: "a", New: "b"},
+		{Kind: Removed, Path: "version", Old: "1.0"},
+	}))
+	g.Ω(Format(changes)).Should(gomega.Equal(`+ keywords.1: "cli"
++ license: "https://spdx.org/licenses/MIT.html"
+~ name: "a" => "b"
+- version: "1.0"`))
+}
+
+func TestDiffEqual(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	a := parseJSON(`{"name": "a", "copyrightYear": 2024}`)
+	b := parseJSON(`{"copyrightYear": 2024.0, "name": "a"}`)
+	g.Ω(Diff(a, b)).Should(gomega.BeEmpty())
+}
+
+func TestDiffTypeChange(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	a := parseJSON(`{"maintainer": {"name": "a"}}`)
+	b := parseJSON(`{"maintainer": [{"name": "a"}]}`)
+	changes := Diff(a, b)
+	g.Ω(changes).Should(gomega.HaveLen(1))
+	g.Ω(changes[0].Kind).Should(gomega.Equal(Changed))
+	g.Ω(changes[0].Path).Should(gomega.Equal("maintainer"))
+}
+
+func TestDiffPartiesByIdentity(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	a := parseJSON(`{"author": [
+		{"@type": "Person", "@id": "https://orcid.org/1", "givenName": "Alice"},
+		{"@type": "Person", "email": "bob@example.org", "givenName": "Bob"},
+		{"@type": "Organization", "name": "Org"}
+	]}`)
+	b := parseJSON(`{"author": [
+		{"@type": "Person", "email": "carol@example.org", "givenName": "Carol"},
+		{"@type": "Person", "email": "bob@example.org", "givenName": "Robert"},
+		{"@type": "Person", "@id": "https://orcid.org/1", "givenName": "Alice"}
+	]}`)
+
+	changes := Diff(a, b)
+	g.Ω(changes).Should(gomega.Equal([]Change{
+		{Kind: Changed, Path: "author.1.givenName", Old: "Bob", New: "Robert"},
+		{Kind: Removed, Path: "author.2", Old: map[string]any{"@type": "Organization", "name": "Org"}},
+		{Kind: Added, Path: "author.0", New: map[string]any{"@type": "Person", "email": "carol@example.org", "givenName": "Carol"}},
+	}))
+}
+
+func TestFormatJSON(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	changes := []Change{
+		{Kind: Added, Path: "a", New: "y"},
+		{Kind: Removed, Path: "b", Old: "x"},
+	}
+	var actual []any
+	oj.Unmarshal([]byte(FormatJSON(changes)), &actual)
+	g.Ω(actual).Should(gomega.Equal([]any{
+		map[string]any{"kind": "added", "path": "a", "new": "y"},
+		map[string]any{"kind": "removed", "path": "b", "old": "x"},
+	}))
+}
+
+func TestFormatColor(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Ω(FormatColor([]Change{{Kind: Added, Path: "a", New: "b"}})).Should(gomega.Equal("\033[32m+ a: \"b\"\033[0m"))
+}
@@ -0,0 +1,197 @@
+// Package editorconfig reads the properties of a file from .editorconfig files, see:
+// https://editorconfig.org
+package editorconfig
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const FileName = ".editorconfig"
+
+// the properties used for JSON files
+const (
+	IndentStyle        = "indent_style"
+	IndentSize         = "indent_size"
+	TabWidth           = "tab_width"
+	EndOfLine          = "end_of_line"
+	InsertFinalNewline = "insert_final_newline"
+)
+
+// Section is a section of an .editorconfig file, its properties apply to the files
+// matching its glob
+type Section struct {
+	Glob       string
+	Properties map[string]string
+}
+
+// File is a parsed .editorconfig file
+type File struct {
+	// whether the .editorconfig files of the parent directories are ignored
+	Root     bool
+	Sections []Section
+}
+
+// parses the content of an .editorconfig file. The names and values of the properties are
+// lower case, except for the globs of the sections.
+func Parse(content string) *File {
+	file := &File{}
+	var section *Section
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			file.Sections = append(file.Sections, Section{Glob: line[1 : len(line)-1], Properties: map[string]string{}})
+			section = &file.Sections[len(file.Sections)-1]
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.ToLower(strings.TrimSpace(value))
+		if section == nil {
+			// the preamble only declares whether the file is the root file
+			if name == "root" {
+				file.Root = value == "true"
+			}
+			continue
+		}
+		section.Properties[name] = value
+	}
+	return file
+}
+
+// reports whether the glob of the section matches the path, relative to the directory of
+// the .editorconfig file and with "/" separators. A glob without "/" matches the file name
+// in any directory.
+func (s Section) Matches(path string) bool {
+	glob := s.Glob
+	switch {
+	case strings.HasPrefix(glob, "/"):
+		glob = glob[1:]
+	case !strings.Contains(glob, "/"):
+		glob = "**/" + glob
+	}
+	re, err := regexp.Compile("^" + globToRegexp(glob) + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(path)
+}
+
+// converts the glob to a regular expression: "*" matches any characters but "/", "**" any
+// characters, "?" any single character, "[abc]" and "[!abc]" a character of a set or not,
+// "{a,b}" any of the alternatives and "{1..3}" an integer of the range
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	braces := 0
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		case c == '*' && strings.HasPrefix(glob[i:], "**/"):
+			// any directories, including none
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			set := glob[i+1 : i+end]
+			if strings.HasPrefix(set, "!") {
+				set = "^" + set[1:]
+			}
+			b.WriteString("[" + set + "]")
+			i += end
+		case c == '{':
+			end := strings.IndexByte(glob[i:], '}')
+			if end > 0 && regexp.MustCompile(`^-?\d+\.\.-?\d+$`).MatchString(glob[i+1:i+end]) {
+				b.WriteString(`-?\d+`)
+				i += end
+				continue
+			}
+			braces++
+			b.WriteString("(?:")
+		case c == '}' && braces > 0:
+			braces--
+			b.WriteString(")")
+		case c == ',' && braces > 0:
+			b.WriteString("|")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// returns the properties of the file from the .editorconfig files of its directory and of
+// the parent directories up to the stop directory, e.g., the root of the git repository,
+// or the first root .editorconfig file. The properties of the closer files and of the
+// later sections take precedence.
+func Lookup(path string, stop string) (map[string]string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	stop, err = filepath.Abs(stop)
+	if err != nil {
+		return nil, err
+	}
+
+	type found struct {
+		dir  string
+		file *File
+	}
+	files := []found{}
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		content, err := os.ReadFile(filepath.Join(dir, FileName))
+		if err == nil {
+			file := Parse(string(content))
+			files = append(files, found{dir: dir, file: file})
+			if file.Root {
+				break
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if dir == stop || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	properties := map[string]string{}
+	// the farthest file first
+	for i := len(files) - 1; i >= 0; i-- {
+		relative, err := filepath.Rel(files[i].dir, path)
+		if err != nil {
+			return nil, err
+		}
+		for _, section := range files[i].file.Sections {
+			if section.Matches(filepath.ToSlash(relative)) {
+				for name, value := range section.Properties {
+					properties[name] = value
+				}
+			}
+		}
+	}
+	return properties, nil
+}
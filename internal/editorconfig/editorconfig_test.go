@@ -0,0 +1,85 @@
+package editorconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	file := Parse(`# top-most
+root = true
+
+[*]
+indent_style = space
+indent_size = 2
+
+; JSON files
+[*.{json,jsonld}]
+Indent_Size = 4
+end_of_line = CRLF
+`)
+	g.Expect(file.Root).To(gomega.BeTrue())
+	g.Ω(file.Sections).Should(gomega.HaveLen(2))
+	g.Ω(file.Sections[1].Glob).Should(gomega.Equal("*.{json,jsonld}"))
+	g.Ω(file.Sections[1].Properties).Should(gomega.Equal(map[string]string{IndentSize: "4", EndOfLine: "crlf"}))
+}
+
+func TestMatches(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	matches := func(glob string, path string) bool {
+		return Section{Glob: glob}.Matches(path)
+	}
+	g.Expect(matches("*", "codemeta.json")).To(gomega.BeTrue())
+	g.Expect(matches("*.json", "sub/dir/codemeta.json")).To(gomega.BeTrue())
+	g.Expect(matches("/*.json", "sub/codemeta.json")).To(gomega.BeFalse())
+	g.Expect(matches("/codemeta.json", "codemeta.json")).To(gomega.BeTrue())
+	g.Expect(matches("sub/*.json", "sub/codemeta.json")).To(gomega.BeTrue())
+	g.Expect(matches("sub/*.json", "other/sub/codemeta.json")).To(gomega.BeFalse())
+	g.Expect(matches("**/codemeta.json", "a/b/codemeta.json")).To(gomega.BeTrue())
+	g.Expect(matches("{codemeta,package}.json", "package.json")).To(gomega.BeTrue())
+	g.Expect(matches("{codemeta,package}.json", "other.json")).To(gomega.BeFalse())
+	g.Expect(matches("code?eta.[jx]son", "codemeta.json")).To(gomega.BeTrue())
+	g.Expect(matches("*.[!j]son", "codemeta.json")).To(gomega.BeFalse())
+	g.Expect(matches("file{1..3}.json", "file2.json")).To(gomega.BeTrue())
+	g.Expect(matches("*.md", "codemeta.json")).To(gomega.BeFalse())
+}
+
+func TestLookup(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	err := os.Mkdir(sub, 0755)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	write := func(path string, content string) {
+		err := os.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	write(filepath.Join(root, FileName), "[*]\nindent_style = space\nindent_size = 2\ninsert_final_newline = true\n\n[*.json]\nindent_size = 4\n")
+	write(filepath.Join(sub, FileName), "[codemeta.json]\nindent_style = tab\n")
+
+	properties, err := Lookup(filepath.Join(sub, "codemeta.json"), root)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(properties).Should(gomega.Equal(map[string]string{IndentStyle: "tab", IndentSize: "4", InsertFinalNewline: "true"}))
+
+	// the files above the stop directory are not read
+	properties, err = Lookup(filepath.Join(sub, "codemeta.json"), sub)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(properties).Should(gomega.Equal(map[string]string{IndentStyle: "tab"}))
+
+	// a root file stops the lookup
+	write(filepath.Join(sub, FileName), "root = true\n")
+	properties, err = Lookup(filepath.Join(sub, "codemeta.json"), root)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(properties).Should(gomega.BeEmpty())
+}
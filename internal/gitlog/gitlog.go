@@ -0,0 +1,136 @@
+package gitlog
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+)
+
+const (
+	SortCommits = "commits"
+	SortRecent  = "recent"
+
+	// separates the fields of a formatted commit
+	separator = "\x1f"
+)
+
+// Identity is a commit author aggregated over all of their commits by email
+type Identity struct {
+	Name    string
+	Email   string
+	Commits int
+	First   time.Time
+	Last    time.Time
+}
+
+// reads the commit authors of the git repository containing the given directory. The
+// author names and emails are mapped through the repository's .mailmap file. The since
+// and until dates (e.g., "2024-01-01") limit the commits read and may be empty.
+func Log(dir string, since string, until string) ([]Identity, error) {
+	args := []string{"-C", dir, "log", "--use-mailmap", "--format=%aN" + separator + "%aE" + separator + "%at"}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	if until != "" {
+		args = append(args, "--until="+until)
+	}
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("unable to read the git log of %s: %s", dir, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("unable to read the git log of %s: %s", dir, err.Error())
+	}
+	return Parse(string(out))
+}
+
+// aggregates the lines of "git log --format=%aN%x1f%aE%x1f%at" output by email, ignoring
+// case. The name of the most recent commit is used, i.e., the first one in the log.
+func Parse(output string) ([]Identity, error) {
+	byEmail := make(map[string]*Identity)
+	identities := []*Identity{}
+	for i, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, separator)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid git log line %d: %q", i+1, line)
+		}
+		seconds, err := strconv.ParseInt(strings.TrimSpace(fields[2]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid git log timestamp on line %d: %q", i+1, fields[2])
+		}
+		date := time.Unix(seconds, 0).UTC()
+		email := strings.TrimSpace(fields[1])
+		key := strings.ToLower(email)
+		identity, ok := byEmail[key]
+		if !ok {
+			identity = &Identity{Name: strings.TrimSpace(fields[0]), Email: email, First: date, Last: date}
+			byEmail[key] = identity
+			identities = append(identities, identity)
+		}
+		identity.Commits++
+		if date.Before(identity.First) {
+			identity.First = date
+		}
+		if date.After(identity.Last) {
+			identity.Last = date
+		}
+	}
+
+	result := make([]Identity, len(identities))
+	for i, identity := range identities {
+		result[i] = *identity
+	}
+	return result, nil
+}
+
+// ranks the identities by commit count or by their most recent commit. Ties are
+// broken by the other criterion and then by name.
+func Sort(identities []Identity, by string) error {
+	byCommits := func(a, b Identity) int { return b.Commits - a.Commits }
+	byRecent := func(a, b Identity) int { return b.Last.Compare(a.Last) }
+	var first, second func(a, b Identity) int
+	switch by {
+	case SortCommits:
+		first, second = byCommits, byRecent
+	case SortRecent:
+		first, second = byRecent, byCommits
+	default:
+		return fmt.Errorf("invalid sort: %s, must be one of: %s, %s", by, SortCommits, SortRecent)
+	}
+	sort.SliceStable(identities, func(i, j int) bool {
+		a, b := identities[i], identities[j]
+		if c := first(a, b); c != 0 {
+			return c < 0
+		}
+		if c := second(a, b); c != 0 {
+			return c < 0
+		}
+		return a.Name < b.Name
+	})
+	return nil
+}
+
+func (i Identity) String() string {
+	commits := "commits"
+	if i.Commits == 1 {
+		commits = "commit"
+	}
+	return fmt.Sprintf("%s <%s> (%d %s, %s to %s)", i.Name, i.Email, i.Commits, commits, i.First.Format("2006-01-02"), i.Last.Format("2006-01-02"))
+}
+
+// returns the identity as a person. The last word of the name is used as the family
+// name and the rest as the given name.
+func (i Identity) Person() *map[string]any {
+	givenName, familyName := model.SplitName(i.Name)
+	email := i.Email
+	id := ""
+	return model.NewPerson(&givenName, &familyName, &email, &id)
+}
@@ -0,0 +1,127 @@
+package gitlog
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	output := "Jane Doe\x1fjane@example.com\x1f1706745600\n" +
+		"John Smith\x1fjohn@example.com\x1f1704067200\n" +
+		"jane\x1fJANE@example.com\x1f1704067200\n"
+	identities, err := Parse(output)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(identities).Should(gomega.HaveLen(2))
+	g.Ω(identities[0]).Should(gomega.Equal(Identity{
+		Name:    "Jane Doe",
+		Email:   "jane@example.com",
+		Commits: 2,
+		First:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Last:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}))
+	g.Ω(identities[1].Commits).Should(gomega.Equal(1))
+
+	_, err = Parse("invalid\n")
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestSort(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	identities := []Identity{
+		{Name: "a", Commits: 1, Last: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "b", Commits: 5, Last: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "c", Commits: 5, Last: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	err := Sort(identities, SortCommits)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω([]string{identities[0].Name, identities[1].Name, identities[2].Name}).Should(gomega.Equal([]string{"c", "b", "a"}))
+
+	err = Sort(identities, SortRecent)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω([]string{identities[0].Name, identities[1].Name, identities[2].Name}).Should(gomega.Equal([]string{"a", "c", "b"}))
+
+	g.Expect(Sort(identities, "name")).ToNot(gomega.BeNil())
+}
+
+func TestPerson(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	person := Identity{Name: "Jane Q. Doe", Email: "jane@example.com"}.Person()
+	g.Ω(*person).Should(gomega.Equal(map[string]any{
+		model.Type:       model.PersonType,
+		model.GivenName:  "Jane Q.",
+		model.FamilyName: "Doe",
+		model.Email:      "jane@example.com",
+		model.Id:         "",
+	}))
+
+	person = Identity{Name: "jane", Email: "jane@example.com"}.Person()
+	g.Ω((*person)[model.GivenName]).Should(gomega.Equal("jane"))
+	g.Ω((*person)[model.FamilyName]).Should(gomega.Equal(""))
+}
+
+func TestLog(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	run := func(env []string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	commit := func(name string, email string, date string) {
+		run([]string{
+			"GIT_AUTHOR_NAME=" + name, "GIT_AUTHOR_EMAIL=" + email, "GIT_AUTHOR_DATE=" + date,
+			"GIT_COMMITTER_NAME=" + name, "GIT_COMMITTER_EMAIL=" + email, "GIT_COMMITTER_DATE=" + date,
+		}, "commit", "--allow-empty", "-q", "-m", "commit")
+	}
+	run(nil, "init", "-q")
+	commit("Jane Doe", "jane@example.com", "2024-01-01T00:00:00Z")
+	commit("jd", "jane@old.example.com", "2024-02-01T00:00:00Z")
+	commit("John Smith", "john@example.com", "2024-03-01T00:00:00Z")
+	os.WriteFile(filepath.Join(dir, ".mailmap"), []byte("Jane Doe <jane@example.com> <jane@old.example.com>\n"), 0644)
+
+	identities, err := Log(dir, "", "")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	err = Sort(identities, SortCommits)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(identities).Should(gomega.HaveLen(2))
+	g.Ω(identities[0].Name).Should(gomega.Equal("Jane Doe"))
+	g.Ω(identities[0].Email).Should(gomega.Equal("jane@example.com"))
+	g.Ω(identities[0].Commits).Should(gomega.Equal(2))
+
+	identities, err = Log(dir, "2024-02-15", "")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(identities).Should(gomega.HaveLen(1))
+	g.Ω(identities[0].Email).Should(gomega.Equal("john@example.com"))
+
+	_, err = Log(t.TempDir(), "", "")
+	g.Expect(err).ToNot(gomega.BeNil())
+}
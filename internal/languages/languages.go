@@ -0,0 +1,143 @@
+package languages
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+)
+
+// Language is a programming language recognized by the extensions of its source files
+type Language struct {
+	Name       string
+	URL        string
+	Extensions []string
+}
+
+// returns the codemeta ComputerLanguage of the language
+func (l Language) Entry() map[string]any {
+	return *model.NewProgrammingLanguage(&l.Name, &l.URL)
+}
+
+// the recognized languages. Markup and data formats, e.g., Markdown or JSON, are not
+// programming languages of the project and ambiguous extensions, e.g., ".m" for MATLAB
+// and Objective-C, are not recognized.
+var Languages = []Language{
+	{Name: "C", URL: "https://www.open-std.org/jtc1/sc22/wg14/", Extensions: []string{".c", ".h"}},
+	{Name: "C#", URL: "https://learn.microsoft.com/dotnet/csharp/", Extensions: []string{".cs"}},
+	{Name: "C++", URL: "https://isocpp.org", Extensions: []string{".cc", ".cpp", ".cxx", ".hh", ".hpp", ".hxx"}},
+	{Name: "CUE", URL: "https://cuelang.org", Extensions: []string{".cue"}},
+	{Name: "Dart", URL: "https://dart.dev", Extensions: []string{".dart"}},
+	{Name: "Elixir", URL: "https://elixir-lang.org", Extensions: []string{".ex", ".exs"}},
+	{Name: "Erlang", URL: "https://www.erlang.org", Extensions: []string{".erl", ".hrl"}},
+	{Name: "Fortran", URL: "https://fortran-lang.org", Extensions: []string{".f", ".f03", ".f08", ".f90", ".f95", ".for"}},
+	{Name: "Go", URL: "https://go.dev", Extensions: []string{".go"}},
+	{Name: "Haskell", URL: "https://www.haskell.org", Extensions: []string{".hs"}},
+	{Name: "Java", URL: "https://www.java.com", Extensions: []string{".java"}},
+	{Name: "JavaScript", URL: "https://developer.mozilla.org/docs/Web/JavaScript", Extensions: []string{".cjs", ".js", ".jsx", ".mjs"}},
+	{Name: "Julia", URL: "https://julialang.org", Extensions: []string{".jl"}},
+	{Name: "Kotlin", URL: "https://kotlinlang.org", Extensions: []string{".kt", ".kts"}},
+	{Name: "Lua", URL: "https://www.lua.org", Extensions: []string{".lua"}},
+	{Name: "OCaml", URL: "https://ocaml.org", Extensions: []string{".ml", ".mli"}},
+	{Name: "Perl", URL: "https://www.perl.org", Extensions: []string{".pl", ".pm"}},
+	{Name: "PHP", URL: "https://www.php.net", Extensions: []string{".php"}},
+	{Name: "Python", URL: "https://www.python.org", Extensions: []string{".py", ".pyx"}},
+	{Name: "R", URL: "https://www.r-project.org", Extensions: []string{".r"}},
+	{Name: "Ruby", URL: "https://www.ruby-lang.org", Extensions: []string{".rb"}},
+	{Name: "Rust", URL: "https://www.rust-lang.org", Extensions: []string{".rs"}},
+	{Name: "Scala", URL: "https://www.scala-lang.org", Extensions: []string{".scala", ".sc"}},
+	{Name: "Shell", URL: "https://www.gnu.org/software/bash/", Extensions: []string{".bash", ".sh", ".zsh"}},
+	{Name: "SQL", URL: "https://www.iso.org/standard/76583.html", Extensions: []string{".sql"}},
+	{Name: "Swift", URL: "https://www.swift.org", Extensions: []string{".swift"}},
+	{Name: "TypeScript", URL: "https://www.typescriptlang.org", Extensions: []string{".cts", ".mts", ".ts", ".tsx"}},
+	{Name: "Zig", URL: "https://ziglang.org", Extensions: []string{".zig"}},
+}
+
+// returns the language of the file name by its extension, ignoring case
+func LanguageOf(name string) (Language, bool) {
+	extension := strings.ToLower(filepath.Ext(name))
+	for _, language := range Languages {
+		for _, e := range language.Extensions {
+			if e == extension {
+				return language, true
+			}
+		}
+	}
+	return Language{}, false
+}
+
+// the directories of dependencies and build outputs which are not scanned, in addition
+// to hidden directories, e.g., ".git"
+var skippedDirs = map[string]bool{
+	"__pycache__":  true,
+	"build":        true,
+	"dist":         true,
+	"node_modules": true,
+	"target":       true,
+	"vendor":       true,
+	"venv":         true,
+}
+
+// Usage is the number of bytes of the source files of a language
+type Usage struct {
+	Language
+	Bytes int64
+}
+
+// returns the share of the usage in the total number of bytes, in percent
+func Share(usage Usage, usages []Usage) float64 {
+	var total int64
+	for _, u := range usages {
+		total += u.Bytes
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(usage.Bytes) * 100 / float64(total)
+}
+
+// scans the files in the directory tree and returns the languages found, ranked by the
+// number of bytes of their source files
+func Detect(dir string) ([]Usage, error) {
+	bytes := map[string]int64{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && (strings.HasPrefix(entry.Name(), ".") || skippedDirs[entry.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		language, ok := LanguageOf(entry.Name())
+		if !ok {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		bytes[language.Name] += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usages := []Usage{}
+	for _, language := range Languages {
+		if n, ok := bytes[language.Name]; ok {
+			usages = append(usages, Usage{Language: language, Bytes: n})
+		}
+	}
+	sort.SliceStable(usages, func(i, j int) bool {
+		return usages[i].Bytes > usages[j].Bytes
+	})
+	return usages, nil
+}
@@ -0,0 +1,60 @@
+package languages
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestLanguageOf(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	language, ok := LanguageOf("main.go")
+	g.Ω(ok).Should(gomega.BeTrue())
+	g.Ω(language.Name).Should(gomega.Equal("Go"))
+
+	language, ok = LanguageOf("Component.TSX")
+	g.Ω(ok).Should(gomega.BeTrue())
+	g.Ω(language.Name).Should(gomega.Equal("TypeScript"))
+
+	_, ok = LanguageOf("README.md")
+	g.Ω(ok).Should(gomega.BeFalse())
+}
+
+func TestDetect(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dir := t.TempDir()
+	files := map[string]int{
+		"main.go":                     300,
+		"internal/db/schema.sql":      50,
+		"web/src/app.ts":              100,
+		"web/src/view.tsx":            100,
+		"web/node_modules/lib/lib.js": 1000, // dependencies are skipped
+		".github/scripts/ci.sh":       1000, // hidden directories are skipped
+		"README.md":                   1000, // not a programming language
+	}
+	for name, size := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0644)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	usages, err := Detect(dir)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	names := []string{}
+	for _, usage := range usages {
+		names = append(names, usage.Name)
+	}
+	g.Ω(names).Should(gomega.Equal([]string{"Go", "TypeScript", "SQL"}))
+	g.Ω(usages[1].Bytes).Should(gomega.Equal(int64(200)))
+	g.Ω(Share(usages[0], usages)).Should(gomega.BeNumerically("~", 54.5, 0.1))
+}
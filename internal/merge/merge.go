@@ -0,0 +1,262 @@
+package merge
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/patch"
+)
+
+const (
+	// scalar conflicts and arrays are resolved with the value of the base document
+	Ours = "ours"
+	// scalar conflicts and arrays are resolved with the value of the other document
+	Theirs = "theirs"
+	// scalar conflicts are resolved with the value of the base document, arrays are unioned
+	UnionArrays = "union-arrays"
+)
+
+var Strategies = []string{Ours, Theirs, UnionArrays}
+
+// Conflict is a scalar property whose value differs between the two documents
+type Conflict struct {
+	Path     string
+	Ours     any
+	Theirs   any
+	Resolved any
+}
+
+func ValidStrategy(strategy string) error {
+	for _, s := range Strategies {
+		if s == strategy {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid merge strategy: %s, must be one of: %s", strategy, strings.Join(Strategies, ", "))
+}
+
+// merges the other document into the base document. Persons and organizations are
+// always de-duplicated by "@id" or email, and keywords are always unioned ignoring
+// case. The strategy decides scalar conflicts and how any other arrays are merged.
+func Merge(base map[string]any, other map[string]any, strategy string) (map[string]any, []Conflict, error) {
+	err := ValidStrategy(strategy)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &merger{strategy: strategy, conflicts: []Conflict{}}
+	result := m.mergeObjects("", base, other)
+	return result, m.conflicts, nil
+}
+
+type merger struct {
+	strategy  string
+	conflicts []Conflict
+}
+
+func join(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func (m *merger) mergeObjects(path string, ours map[string]any, theirs map[string]any) map[string]any {
+	result := make(map[string]any, len(ours))
+	keys := make([]string, 0, len(ours)+len(theirs))
+	for key := range ours {
+		keys = append(keys, key)
+	}
+	for key := range theirs {
+		if _, ok := ours[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	// sorted so that conflicts are reported in a stable order
+	sort.Strings(keys)
+	for _, key := range keys {
+		left, inOurs := ours[key]
+		right, inTheirs := theirs[key]
+		switch {
+		case !inTheirs:
+			result[key] = patch.DeepCopy(left)
+		case !inOurs:
+			result[key] = patch.DeepCopy(right)
+		default:
+			result[key] = m.mergeValues(join(path, key), key, left, right)
+		}
+	}
+	return result
+}
+
+func (m *merger) mergeValues(path string, key string, ours any, theirs any) any {
+	if patch.Equal(ours, theirs) {
+		return patch.DeepCopy(ours)
+	}
+	if key == model.Keywords {
+		return unionKeywords(ours, theirs)
+	}
+	if isParties(ours) || isParties(theirs) {
+		return m.mergeParties(path, ours, theirs)
+	}
+
+	left, leftIsObject := ours.(map[string]any)
+	right, rightIsObject := theirs.(map[string]any)
+	if leftIsObject && rightIsObject {
+		return m.mergeObjects(path, left, right)
+	}
+
+	leftArray, leftIsArray := ours.([]any)
+	rightArray, rightIsArray := theirs.([]any)
+	if leftIsArray && rightIsArray {
+		switch m.strategy {
+		case Theirs:
+			return patch.DeepCopy(theirs)
+		case UnionArrays:
+			return union(leftArray, rightArray)
+		default:
+			return patch.DeepCopy(ours)
+		}
+	}
+
+	resolved := ours
+	if m.strategy == Theirs {
+		resolved = theirs
+	}
+	m.conflicts = append(m.conflicts, Conflict{Path: path, Ours: ours, Theirs: theirs, Resolved: resolved})
+	return patch.DeepCopy(resolved)
+}
+
+func union(ours []any, theirs []any) []any {
+	result := patch.DeepCopy(ours).([]any)
+	for _, elem := range theirs {
+		found := false
+		for _, existing := range result {
+			if patch.Equal(existing, elem) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, patch.DeepCopy(elem))
+		}
+	}
+	return result
+}
+
+func toList(value any) []any {
+	if arr, ok := value.([]any); ok {
+		return arr
+	}
+	return []any{value}
+}
+
+func unionKeywords(ours any, theirs any) []any {
+	result := []any{}
+	seen := make(map[string]bool)
+	for _, keyword := range append(toList(ours), toList(theirs)...) {
+		if s, ok := keyword.(string); ok {
+			if seen[strings.ToLower(s)] {
+				continue
+			}
+			seen[strings.ToLower(s)] = true
+			result = append(result, s)
+			continue
+		}
+		// non-string keywords, e.g., a DefinedTerm
+		found := false
+		for _, existing := range result {
+			if patch.Equal(existing, keyword) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, patch.DeepCopy(keyword))
+		}
+	}
+	return result
+}
+
+func isParty(value any) bool {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	t := m[model.Type]
+	return t == model.PersonType || t == model.OrganizationType
+}
+
+// returns true when the value is a person or organization or a non-empty list of them
+func isParties(value any) bool {
+	if arr, ok := value.([]any); ok {
+		if len(arr) == 0 {
+			return false
+		}
+		for _, elem := range arr {
+			if !isParty(elem) {
+				return false
+			}
+		}
+		return true
+	}
+	return isParty(value)
+}
+
+// returns the identities of a person or organization, its "@id" and email
+func Identities(value any) []string {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	identities := []string{}
+	if id, ok := m[model.Id].(string); ok && id != "" {
+		identities = append(identities, "@id:"+id)
+	}
+	if email, ok := m[model.Email].(string); ok && email != "" {
+		identities = append(identities, "email:"+strings.ToLower(email))
+	}
+	return identities
+}
+
+// returns true when both values are the same person or organization
+func SameParty(a any, b any) bool {
+	for _, x := range Identities(a) {
+		for _, y := range Identities(b) {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return patch.Equal(a, b)
+}
+
+func (m *merger) mergeParties(path string, ours any, theirs any) any {
+	_, oursIsArray := ours.([]any)
+	_, theirsIsArray := theirs.([]any)
+
+	result := patch.DeepCopy(toList(ours)).([]any)
+	for _, party := range toList(theirs) {
+		matched := false
+		for i, existing := range result {
+			if SameParty(existing, party) {
+				left, leftIsObject := existing.(map[string]any)
+				right, rightIsObject := party.(map[string]any)
+				if leftIsObject && rightIsObject {
+					result[i] = m.mergeObjects(join(path, strconv.Itoa(i)), left, right)
+				}
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			result = append(result, patch.DeepCopy(party))
+		}
+	}
+	if len(result) == 1 && !oursIsArray && !theirsIsArray {
+		return result[0]
+	}
+	return result
+}
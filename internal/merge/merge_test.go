@@ -0,0 +1,101 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/oj"
+	"github.com/onsi/gomega"
+)
+
+func parseJSON(s string) map[string]any {
+	var m map[string]any
+	oj.Unmarshal([]byte(s), &m)
+	return m
+}
+
+var base = parseJSON(`{
+	"@context": "https://w3id.org/codemeta/3.0",
+	"@type": "SoftwareSourceCode",
+	"name": "project",
+	"version": "1.0.0",
+	"keywords": ["Go", "CLI"],
+	"processorRequirements": ["x86"],
+	"author": [
+		{"@type": "Person", "@id": "https://orcid.org/0000-0000-0000-0001", "givenName": "Alice", "familyName": "Smith"},
+		{"@type": "Person", "givenName": "Bob", "email": "bob@example.org"}
+	],
+	"maintainer": {"@type": "Organization", "@id": "https://ror.org/00000000", "name": "Org"}
+}`)
+
+var other = parseJSON(`{
+	"@context": "https://w3id.org/codemeta/3.0",
+	"@type": "SoftwareSourceCode",
+	"name": "project",
+	"version": "1.1.0",
+	"description": "description",
+	"keywords": ["go", "codemeta"],
+	"processorRequirements": ["arm64"],
+	"author": [
+		{"@type": "Person", "givenName": "Bob", "familyName": "Jones", "email": "BOB@example.org"},
+		{"@type": "Person", "@id": "https://orcid.org/0000-0000-0000-0001", "givenName": "Alice", "familyName": "Smith-Jones"},
+		{"@type": "Person", "givenName": "Carol", "email": "carol@example.org"}
+	],
+	"maintainer": {"@type": "Organization", "@id": "https://ror.org/00000000", "name": "Org", "url": "https://org.example"}
+}`)
+
+func TestMergeOurs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	result, conflicts, err := Merge(base, other, Ours)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(result["version"]).Should(gomega.Equal("1.0.0"))
+	g.Ω(result["description"]).Should(gomega.Equal("description"))
+	g.Ω(result["keywords"]).Should(gomega.Equal([]any{"Go", "CLI", "codemeta"}))
+	g.Ω(result["processorRequirements"]).Should(gomega.Equal([]any{"x86"}))
+	g.Ω(result["author"]).Should(gomega.Equal([]any{
+		map[string]any{"@type": "Person", "@id": "https://orcid.org/0000-0000-0000-0001", "givenName": "Alice", "familyName": "Smith"},
+		map[string]any{"@type": "Person", "givenName": "Bob", "familyName": "Jones", "email": "bob@example.org"},
+		map[string]any{"@type": "Person", "givenName": "Carol", "email": "carol@example.org"},
+	}))
+	// single values stay single values
+	g.Ω(result["maintainer"]).Should(gomega.Equal(map[string]any{"@type": "Organization", "@id": "https://ror.org/00000000", "name": "Org", "url": "https://org.example"}))
+
+	g.Ω(conflicts).Should(gomega.Equal([]Conflict{
+		{Path: "author.1.email", Ours: "bob@example.org", Theirs: "BOB@example.org", Resolved: "bob@example.org"},
+		{Path: "author.0.familyName", Ours: "Smith", Theirs: "Smith-Jones", Resolved: "Smith"},
+		{Path: "version", Ours: "1.0.0", Theirs: "1.1.0", Resolved: "1.0.0"},
+	}))
+}
+
+func TestMergeTheirs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	result, conflicts, err := Merge(base, other, Theirs)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(result["version"]).Should(gomega.Equal("1.1.0"))
+	g.Ω(result["processorRequirements"]).Should(gomega.Equal([]any{"arm64"}))
+	g.Ω(conflicts).Should(gomega.HaveLen(3))
+	g.Ω(conflicts[2].Resolved).Should(gomega.Equal("1.1.0"))
+}
+
+func TestMergeUnionArrays(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	result, _, err := Merge(base, other, UnionArrays)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(result["version"]).Should(gomega.Equal("1.0.0"))
+	g.Ω(result["processorRequirements"]).Should(gomega.Equal([]any{"x86", "arm64"}))
+}
+
+func TestMergeInvalidStrategy(t *testing.T) {
+	_, _, err := Merge(base, other, "mine")
+	if err == nil {
+		t.Errorf("Expected error")
+	}
+}
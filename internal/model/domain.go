@@ -1,5 +1,7 @@
 package model
 
+import "strings"
+
 const (
 	// Default Supported JSON Keys
 	Type                  = "@type"
@@ -26,15 +28,83 @@ const (
 	Readme                = "readme"
 	ContinuousIntegration = "continuousIntegration"
 	DevelopmentStatus     = "developmentStatus"
+	DateModified          = "dateModified"
+	DatePublished         = "datePublished"
 	URL                   = "url"
+	Funding               = "funding"
+	Funder                = "funder"
+	SoftwareRequirements  = "softwareRequirements"
+	SoftwareSuggestions   = "softwareSuggestions"
 	// Implementation Values
-	DefaultContext         = "https://w3id.org/codemeta/3.0"
-	PersonType             = "Person"
-	OrganizationType       = "Organization"
-	SoftwareSourceCodeType = "SoftwareSourceCode"
-	ComputerLanguageType   = "ComputerLanguage"
+	DefaultContext          = "https://w3id.org/codemeta/3.0"
+	PersonType              = "Person"
+	OrganizationType        = "Organization"
+	SoftwareSourceCodeType  = "SoftwareSourceCode"
+	SoftwareApplicationType = "SoftwareApplication"
+	ComputerLanguageType    = "ComputerLanguage"
+	GrantType               = "Grant"
+)
+
+// the supported "@context" values, see the schema in internal/cue
+var Contexts = []string{
+	DefaultContext,
+	"https://raw.githubusercontent.com/codemeta/codemeta/master/codemeta.json",
+	"https://doi.org/10.5063/schema/codemeta-2.0",
+	"https://raw.githubusercontent.com/codemeta/codemeta/master/codemeta.jsonld",
+}
+
+// the canonical order of the properties of codemeta.json files and of their persons and
+// organizations, properties which are not listed follow in alphabetical order
+var CanonicalOrder = []string{
+	// what the software is
+	Context, Type, Id, Identifier, Name, GivenName, FamilyName, Email, Description, Version,
+	"applicationCategory", Keywords, DevelopmentStatus,
+	// where it is
+	URL, CodeRepository, IssueTracker, ContinuousIntegration, "contIntegration", Readme,
+	"buildInstructions", "downloadUrl", "installUrl", RelatedLink,
+	// its terms
+	License, "copyrightHolder", "copyrightYear",
+	// its releases
+	"dateCreated", DateModified, DatePublished, ReleaseNotes,
+	// what it runs on
+	ProgrammingLanguage, RuntimePlatform, "operatingSystem", SoftwareRequirements, SoftwareSuggestions,
+	// its people
+	Author, Contributor, Maintainer, "affiliation", Funder, Funding,
+}
+
+// the CodeMeta versions, each with its own schema in internal/cue
+const (
+	CodeMeta20     = "2.0"
+	CodeMeta30     = "3.0"
+	DefaultVersion = CodeMeta30
 )
 
+var Versions = []string{CodeMeta20, CodeMeta30}
+
+// returns the CodeMeta version of the "@context", the "master" contexts refer to the
+// latest version
+func VersionOf(context string) (string, bool) {
+	switch context {
+	case "https://doi.org/10.5063/schema/codemeta-2.0":
+		return CodeMeta20, true
+	case DefaultContext, "https://raw.githubusercontent.com/codemeta/codemeta/master/codemeta.json", "https://raw.githubusercontent.com/codemeta/codemeta/master/codemeta.jsonld":
+		return CodeMeta30, true
+	}
+	return "", false
+}
+
+// returns the continuousIntegration property of the "@context", which is contIntegration
+// in CodeMeta 2.0
+func ContinuousIntegrationOf(context string) string {
+	if version, _ := VersionOf(context); version == CodeMeta20 {
+		return "contIntegration"
+	}
+	return ContinuousIntegration
+}
+
+// the "@context" of new codemeta files, see the "context" configuration key
+var ConfiguredContext = DefaultContext
+
 type LicenseStruct struct {
 	Reference             string   `json:"reference"`
 	IsDeprecatedLicenseId bool     `json:"isDeprecatedLicenseId"`
@@ -67,6 +137,34 @@ func NewPerson(givenName *string, familyName *string, email *string, id *string)
 	}
 }
 
+// splits a full name into a given name and a family name, the last word is used as the
+// family name
+func SplitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	if index := strings.LastIndex(name, " "); index >= 0 {
+		return strings.TrimSpace(name[:index]), name[index+1:]
+	}
+	return name, ""
+}
+
+// returns a short description of a person or organization, e.g., "Jane Doe <jane@example.org>"
+func PartyLabel(party map[string]any) string {
+	str := func(key string) string {
+		s, _ := party[key].(string)
+		return s
+	}
+	label := str(Name)
+	if party[Type] == PersonType || label == "" {
+		label = strings.TrimSpace(str(GivenName) + " " + str(FamilyName))
+	}
+	if email := str(Email); email != "" {
+		label += " <" + email + ">"
+	} else if id := str(Id); id != "" {
+		label += " (" + id + ")"
+	}
+	return strings.TrimSpace(label)
+}
+
 func NewOrganization(name *string, url *string, id *string) *map[string]any {
 	return &map[string]any{
 		Type: OrganizationType,
@@ -76,6 +174,20 @@ func NewOrganization(name *string, url *string, id *string) *map[string]any {
 	}
 }
 
+// returns a CodeMeta 3.0 funding entry, a Grant with the identifier (grant number) and
+// name given by the funder. An empty name is omitted.
+func NewGrant(identifier *string, name *string, funder map[string]any) *map[string]any {
+	grant := map[string]any{
+		Type:       GrantType,
+		Identifier: *identifier,
+		Funder:     funder,
+	}
+	if *name != "" {
+		grant[Name] = *name
+	}
+	return &grant
+}
+
 func NewProgrammingLanguage(name *string, url *string) *map[string]any {
 	return &map[string]any{
 		Type: ComputerLanguageType,
@@ -86,7 +198,7 @@ func NewProgrammingLanguage(name *string, url *string) *map[string]any {
 
 func NewCodemeta(base *map[string]any) *map[string]any {
 	m := *base
-	m[Context] = DefaultContext
+	m[Context] = ConfiguredContext
 	m[Type] = SoftwareSourceCodeType
 	return &m
 }
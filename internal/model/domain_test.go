@@ -65,3 +65,57 @@ func TestNewCodeMetaDefinition(t *testing.T) {
 
 	g.Ω(*actual).Should(gomega.Equal(expected))
 }
+
+func TestSplitName(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	givenName, familyName := SplitName(" Jane Q. Doe ")
+	g.Ω(givenName).Should(gomega.Equal("Jane Q."))
+	g.Ω(familyName).Should(gomega.Equal("Doe"))
+
+	givenName, familyName = SplitName("jane")
+	g.Ω(givenName).Should(gomega.Equal("jane"))
+	g.Ω(familyName).Should(gomega.Equal(""))
+}
+
+func TestPartyLabel(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Ω(PartyLabel(map[string]any{Type: PersonType, GivenName: "Jane", FamilyName: "Doe", Email: "jane@example.com"})).Should(gomega.Equal("Jane Doe <jane@example.com>"))
+	g.Ω(PartyLabel(map[string]any{Type: OrganizationType, Name: "Org", Id: "https://ror.org/05dxps055"})).Should(gomega.Equal("Org (https://ror.org/05dxps055)"))
+}
+
+func TestPartyProperty(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	property, ok := LookupPartyProperty("copyrightHolder")
+	g.Ω(ok).Should(gomega.BeTrue())
+	g.Ω(property.Label()).Should(gomega.Equal("copyright holder"))
+	g.Ω(property.Flag()).Should(gomega.Equal("copyright-holder"))
+	g.Ω(property.Types()).Should(gomega.Equal("a Person or an Organization"))
+
+	editor, _ := LookupPartyProperty("editor")
+	g.Ω(editor.Types()).Should(gomega.Equal("a Person"))
+	g.Ω(editor.Accepts(map[string]any{Type: PersonType})).Should(gomega.BeTrue())
+	g.Ω(editor.Accepts(map[string]any{Type: OrganizationType})).Should(gomega.BeFalse())
+
+	_, ok = LookupPartyProperty("keywords")
+	g.Ω(ok).Should(gomega.BeFalse())
+}
+
+func TestVersionOf(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for _, context := range Contexts {
+		version, ok := VersionOf(context)
+		g.Expect(ok).To(gomega.BeTrue(), context)
+		g.Ω(Versions).Should(gomega.ContainElement(version))
+	}
+	version, _ := VersionOf("https://doi.org/10.5063/schema/codemeta-2.0")
+	g.Ω(version).Should(gomega.Equal(CodeMeta20))
+	_, ok := VersionOf("https://example.org")
+	g.Expect(ok).To(gomega.BeFalse())
+
+	g.Ω(ContinuousIntegrationOf("https://doi.org/10.5063/schema/codemeta-2.0")).Should(gomega.Equal("contIntegration"))
+	g.Ω(ContinuousIntegrationOf(DefaultContext)).Should(gomega.Equal(ContinuousIntegration))
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PartyProperty is a property of a SoftwareSourceCode whose values are persons and/or
+// organizations, see the schema in internal/cue
+type PartyProperty struct {
+	Name         string
+	Person       bool
+	Organization bool
+	// whether the property accepts a list of values rather than only a single value
+	Multiple bool
+}
+
+// the person and organization valued properties of the schema, kept in sync with the
+// schema by a test in internal/cue
+var PartyProperties = []PartyProperty{
+	{Name: "accountablePerson", Person: true, Multiple: true},
+	{Name: Author, Person: true, Organization: true, Multiple: true},
+	{Name: "character", Person: true},
+	{Name: Contributor, Person: true, Organization: true, Multiple: true},
+	{Name: "copyrightHolder", Person: true, Organization: true, Multiple: true},
+	{Name: "creator", Person: true, Organization: true, Multiple: true},
+	{Name: "editor", Person: true, Multiple: true},
+	{Name: Funder, Person: true, Organization: true, Multiple: true},
+	{Name: Maintainer, Person: true, Organization: true, Multiple: true},
+	{Name: "producer", Person: true, Organization: true, Multiple: true},
+	{Name: "provider", Person: true, Organization: true, Multiple: true},
+	{Name: "publisher", Person: true, Organization: true, Multiple: true},
+	{Name: "publisherImprint", Organization: true},
+	{Name: "sdPublisher", Person: true, Organization: true},
+	{Name: "sourceOrganization", Organization: true},
+	{Name: "sponsor", Person: true, Organization: true, Multiple: true},
+	{Name: "translator", Person: true, Organization: true, Multiple: true},
+}
+
+func LookupPartyProperty(name string) (PartyProperty, bool) {
+	for _, property := range PartyProperties {
+		if property.Name == name {
+			return property, true
+		}
+	}
+	return PartyProperty{}, false
+}
+
+// returns the words of the property name, e.g., "copyright holder" for "copyrightHolder"
+func (p PartyProperty) Label() string {
+	var b strings.Builder
+	for i, r := range p.Name {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// returns the property name in kebab case, e.g., "copyright-holder" for "copyrightHolder"
+func (p PartyProperty) Flag() string {
+	return strings.ReplaceAll(p.Label(), " ", "-")
+}
+
+// returns a description of the accepted types, e.g., "a Person or an Organization"
+func (p PartyProperty) Types() string {
+	switch {
+	case p.Person && p.Organization:
+		return "a " + PersonType + " or an " + OrganizationType
+	case p.Person:
+		return "a " + PersonType
+	default:
+		return "an " + OrganizationType
+	}
+}
+
+// reports whether the person or organization is accepted by the property
+func (p PartyProperty) Accepts(party map[string]any) bool {
+	switch party[Type] {
+	case PersonType:
+		return p.Person
+	case OrganizationType:
+		return p.Organization
+	}
+	return false
+}
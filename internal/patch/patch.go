@@ -0,0 +1,412 @@
+package patch
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ohler55/ojg/oj"
+)
+
+// Operation is a single RFC 6902 JSON Patch operation
+type Operation struct {
+	Op    string
+	Path  string
+	From  string
+	Value any
+}
+
+// Patch is either an RFC 6902 JSON Patch (a list of operations) or an RFC 7396
+// JSON Merge Patch (an object merged into the document)
+type Patch struct {
+	Operations []Operation
+	Merge      map[string]any
+}
+
+func (p *Patch) IsMergePatch() bool {
+	return p.Merge != nil
+}
+
+// parses the given bytes as a JSON Patch when the top-level value is an array or
+// as a JSON Merge Patch when it is an object
+func Parse(bytes []byte) (*Patch, error) {
+	var raw any
+	err := oj.Unmarshal(bytes, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse patch: %s", err.Error())
+	}
+	switch value := raw.(type) {
+	case map[string]any:
+		return &Patch{Merge: value}, nil
+	case []any:
+		operations := make([]Operation, len(value))
+		for i, elem := range value {
+			op, err := parseOperation(elem)
+			if err != nil {
+				return nil, fmt.Errorf("invalid operation at index %d: %s", i, err.Error())
+			}
+			operations[i] = *op
+		}
+		return &Patch{Operations: operations}, nil
+	default:
+		return nil, fmt.Errorf("a patch must be either a JSON Patch array or a JSON Merge Patch object")
+	}
+}
+
+func parseOperation(elem any) (*Operation, error) {
+	m, ok := elem.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("operation must be an object")
+	}
+	var op Operation
+	var err error
+	if op.Op, err = stringMember(m, "op"); err != nil {
+		return nil, err
+	}
+	if op.Path, err = stringMember(m, "path"); err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		value, ok := m["value"]
+		if !ok {
+			return nil, fmt.Errorf("missing \"value\" for %s operation", op.Op)
+		}
+		op.Value = value
+	case "move", "copy":
+		if op.From, err = stringMember(m, "from"); err != nil {
+			return nil, err
+		}
+	case "remove":
+	default:
+		return nil, fmt.Errorf("unknown operation: %s", op.Op)
+	}
+	return &op, nil
+}
+
+func stringMember(m map[string]any, key string) (string, error) {
+	value, ok := m[key]
+	if !ok {
+		return "", fmt.Errorf("missing \"%s\"", key)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("\"%s\" must be a string", key)
+	}
+	return s, nil
+}
+
+// applies the patch to a copy of the document. Either every operation is applied
+// or an error is returned and the document is left unchanged.
+func (p *Patch) Apply(doc any) (any, error) {
+	result := DeepCopy(doc)
+	if p.IsMergePatch() {
+		return MergePatch(result, p.Merge), nil
+	}
+	var err error
+	for i, op := range p.Operations {
+		result, err = apply(result, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s) failed: %s", i, op.Op, op.Path, err.Error())
+		}
+	}
+	return result, nil
+}
+
+func apply(doc any, op Operation) (any, error) {
+	switch op.Op {
+	case "add":
+		return add(doc, op.Path, DeepCopy(op.Value))
+	case "remove":
+		result, _, err := remove(doc, op.Path)
+		return result, err
+	case "replace":
+		result, _, err := remove(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		return add(result, op.Path, DeepCopy(op.Value))
+	case "move":
+		if op.From == op.Path {
+			return doc, nil
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("cannot move a value into one of its children")
+		}
+		result, value, err := remove(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(result, op.Path, value)
+	case "copy":
+		value, err := Get(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, DeepCopy(value))
+	case "test":
+		value, err := Get(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !Equal(value, op.Value) {
+			return nil, fmt.Errorf("test failed, value is %s", oj.JSON(value, &oj.Options{Sort: true}))
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown operation: %s", op.Op)
+	}
+}
+
+// splits an RFC 6901 JSON Pointer into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer: %s", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	// leading zeros are not allowed
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index: %s", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index: %s", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if i > max {
+		return 0, fmt.Errorf("array index out of bounds: %d", i)
+	}
+	return i, nil
+}
+
+// returns the value referenced by the JSON pointer
+func Get(doc any, pointer string) (any, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path not found: %s", pointer)
+			}
+			current = value
+		case []any:
+			i, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[i]
+		default:
+			return nil, fmt.Errorf("path not found: %s", pointer)
+		}
+	}
+	return current, nil
+}
+
+// replaces the child of the container referenced by the last token of the path
+// using the given function, returning the (possibly new) root document
+func update(doc any, tokens []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+	switch node := doc.(type) {
+	case map[string]any:
+		child, ok := node[tokens[0]]
+		if !ok {
+			return nil, fmt.Errorf("path not found: /%s", tokens[0])
+		}
+		updated, err := update(child, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[tokens[0]] = updated
+		return node, nil
+	case []any:
+		i, err := arrayIndex(tokens[0], len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := update(node[i], tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = updated
+		return node, nil
+	default:
+		return nil, fmt.Errorf("path not found: /%s", tokens[0])
+	}
+}
+
+func add(doc any, pointer string, value any) (any, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		// replaces the whole document
+		return value, nil
+	}
+	return update(doc, tokens, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			i, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("path not found: %s", pointer)
+		}
+	})
+}
+
+func remove(doc any, pointer string) (any, any, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, doc, nil
+	}
+	var removed any
+	result, err := update(doc, tokens, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path not found: %s", pointer)
+			}
+			removed = value
+			delete(node, token)
+			return node, nil
+		case []any:
+			i, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[i]
+			return append(node[:i:i], node[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("path not found: %s", pointer)
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, removed, nil
+}
+
+// applies an RFC 7396 JSON Merge Patch to the target
+func MergePatch(target any, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return DeepCopy(patch)
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any)
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+		} else {
+			t[key] = MergePatch(t[key], value)
+		}
+	}
+	return t
+}
+
+func DeepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, elem := range v {
+			m[key] = DeepCopy(elem)
+		}
+		return m
+	case []any:
+		arr := make([]any, len(v))
+		for i, elem := range v {
+			arr[i] = DeepCopy(elem)
+		}
+		return arr
+	default:
+		return v
+	}
+}
+
+// compares two JSON values, treating numbers of different Go types as equal when
+// their values are
+func Equal(a, b any) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, elem := range x {
+			other, ok := y[key]
+			if !ok || !Equal(elem, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !Equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
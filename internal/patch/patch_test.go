@@ -0,0 +1,134 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/ohler55/ojg/oj"
+	"github.com/onsi/gomega"
+)
+
+func parseJSON(t *testing.T, s string) any {
+	var v any
+	err := oj.Unmarshal([]byte(s), &v)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return v
+}
+
+func testPatch(t *testing.T, doc string, patch string, expected string) {
+	t.Helper()
+	g := gomega.NewWithT(t)
+
+	p, err := Parse([]byte(patch))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := p.Apply(parseJSON(t, doc))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g.Expect(Equal(result, parseJSON(t, expected))).To(gomega.BeTrue(), oj.JSON(result))
+}
+
+func testPatchError(t *testing.T, doc string, patch string) {
+	t.Helper()
+
+	p, err := Parse([]byte(patch))
+	if err != nil {
+		return
+	}
+	original := parseJSON(t, doc)
+	_, err = p.Apply(original)
+	if err == nil {
+		t.Errorf("Expected error")
+	}
+	// the original document is never modified
+	if !Equal(original, parseJSON(t, doc)) {
+		t.Errorf("Document was modified")
+	}
+}
+
+// examples from https://datatracker.ietf.org/doc/html/rfc6902#appendix-A
+func TestAddObjectMember(t *testing.T) {
+	testPatch(t, `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`)
+}
+
+func TestAddArrayElement(t *testing.T) {
+	testPatch(t, `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`)
+}
+
+func TestAddArrayEnd(t *testing.T) {
+	testPatch(t, `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`)
+}
+
+func TestRemoveObjectMember(t *testing.T) {
+	testPatch(t, `{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`)
+}
+
+func TestRemoveArrayElement(t *testing.T) {
+	testPatch(t, `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`)
+}
+
+func TestReplaceValue(t *testing.T) {
+	testPatch(t, `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`)
+}
+
+func TestMoveValue(t *testing.T) {
+	testPatch(t,
+		`{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+		`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+		`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`)
+}
+
+func TestMoveArrayElement(t *testing.T) {
+	testPatch(t, `{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`)
+}
+
+func TestCopyValue(t *testing.T) {
+	testPatch(t, `{"foo": {"bar": 1}}`, `[{"op": "copy", "from": "/foo", "path": "/baz"}]`, `{"foo": {"bar": 1}, "baz": {"bar": 1}}`)
+}
+
+func TestTestValue(t *testing.T) {
+	testPatch(t, `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+		`[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2.0}]`,
+		`{"baz": "qux", "foo": ["a", 2, "c"]}`)
+}
+
+func TestTestValueFailure(t *testing.T) {
+	testPatchError(t, `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/foo", "value": "x"}, {"op": "test", "path": "/baz", "value": "bar"}]`)
+}
+
+func TestEscapedPointer(t *testing.T) {
+	testPatch(t, `{"/": 9, "~1": 10}`, `[{"op": "test", "path": "/~01", "value": 10}, {"op": "remove", "path": "/~1"}]`, `{"~1": 10}`)
+}
+
+func TestPatchErrors(t *testing.T) {
+	// nonexistent target
+	testPatchError(t, `{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`)
+	// array index out of bounds
+	testPatchError(t, `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/2", "value": "qux"}]`)
+	// invalid array index
+	testPatchError(t, `{"foo": ["bar"]}`, `[{"op": "remove", "path": "/foo/01"}]`)
+	// move into a child
+	testPatchError(t, `{"foo": {"bar": {}}}`, `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`)
+	// remove a missing member
+	testPatchError(t, `{"foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, p := range []string{`"string"`, `[{"op": "add", "path": "/a"}]`, `[{"op": "unknown", "path": "/a"}]`, `[{"path": "/a"}]`, `[{"op": "move", "path": "/a"}]`, `[1]`} {
+		_, err := Parse([]byte(p))
+		if err == nil {
+			t.Errorf("Expected error for %s", p)
+		}
+	}
+}
+
+// example from https://datatracker.ietf.org/doc/html/rfc7396#section-3
+func TestMergePatch(t *testing.T) {
+	testPatch(t,
+		`{"title": "Goodbye!", "author": {"givenName": "John", "familyName": "Doe"}, "tags": ["example", "sample"], "content": "This will be unchanged"}`,
+		`{"title": "Hello!", "phoneNumber": "+01-123-456-7890", "author": {"familyName": null}, "tags": ["example"]}`,
+		`{"title": "Hello!", "author": {"givenName": "John"}, "tags": ["example"], "content": "This will be unchanged", "phoneNumber": "+01-123-456-7890"}`)
+}
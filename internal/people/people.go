@@ -0,0 +1,101 @@
+package people
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cacoco/codemetagenerator/internal/merge"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/ohler55/ojg/oj"
+)
+
+const fileName = "people.json"
+
+func GetFilePath(basedir string) string {
+	return utils.GetConfigDir(basedir) + "/" + fileName
+}
+
+// loads the address book, which is empty when it has not been saved yet
+func Load(basedir string) ([]map[string]any, error) {
+	path := GetFilePath(basedir)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return []map[string]any{}, nil
+	}
+	bytes, err := utils.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []map[string]any
+	err = oj.Unmarshal(bytes, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", path, err.Error())
+	}
+	return entries, nil
+}
+
+func Save(basedir string, entries []map[string]any) error {
+	err := utils.MkHomeDir(basedir)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(GetFilePath(basedir), utils.FormatJSON(entries))
+}
+
+// returns an error when the entry is not a person or an organization with an "@id" or
+// email to key it by
+func Valid(entry map[string]any) error {
+	if entry[model.Type] != model.PersonType && entry[model.Type] != model.OrganizationType {
+		return fmt.Errorf("%s is not a %s or an %s", model.PartyLabel(entry), model.PersonType, model.OrganizationType)
+	}
+	if len(merge.Identities(entry)) == 0 {
+		return fmt.Errorf("%s has neither an @id nor an email", model.PartyLabel(entry))
+	}
+	return nil
+}
+
+// adds the entry to the address book, replacing an entry with the same "@id" or email.
+// Returns true when an entry was replaced.
+func Upsert(entries []map[string]any, entry map[string]any) ([]map[string]any, bool) {
+	for i, existing := range entries {
+		if merge.SameParty(existing, entry) {
+			entries[i] = entry
+			return entries, true
+		}
+	}
+	return append(entries, entry), false
+}
+
+// returns the persons and organizations of a JSON document, which is either an array of
+// entries or a codemeta file whose authors, contributors, maintainers, etc. are returned
+func Parse(bytes []byte) ([]map[string]any, error) {
+	var document any
+	err := oj.Unmarshal(bytes, &document)
+	if err != nil {
+		return nil, err
+	}
+	values := []any{}
+	switch d := document.(type) {
+	case []any:
+		values = d
+	case map[string]any:
+		for _, property := range model.PartyProperties {
+			switch v := d[property.Name].(type) {
+			case []any:
+				values = append(values, v...)
+			case map[string]any:
+				values = append(values, v)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("expected an array of persons and organizations or a codemeta file")
+	}
+
+	entries := []map[string]any{}
+	for _, value := range values {
+		if m, ok := value.(map[string]any); ok {
+			entries = append(entries, m)
+		}
+	}
+	return entries, nil
+}
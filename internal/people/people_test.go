@@ -0,0 +1,82 @@
+package people
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/onsi/gomega"
+)
+
+var jane = map[string]any{model.Type: model.PersonType, model.GivenName: "Jane", model.Email: "jane@example.com"}
+var org = map[string]any{model.Type: model.OrganizationType, model.Name: "Org", model.Id: "https://ror.org/05dxps055"}
+
+func TestLoadAndSave(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	os.Mkdir(utils.GetHomeDir(temp), 0755)
+
+	entries, err := Load(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(entries).Should(gomega.BeEmpty())
+
+	err = Save(temp, []map[string]any{jane, org})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	entries, err = Load(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(entries).Should(gomega.Equal([]map[string]any{jane, org}))
+}
+
+func TestUpsert(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	entries, replaced := Upsert([]map[string]any{jane}, org)
+	g.Ω(replaced).Should(gomega.BeFalse())
+	g.Ω(entries).Should(gomega.HaveLen(2))
+
+	updated := map[string]any{model.Type: model.PersonType, model.GivenName: "Jane", model.FamilyName: "Doe", model.Email: "JANE@example.com"}
+	entries, replaced = Upsert(entries, updated)
+	g.Ω(replaced).Should(gomega.BeTrue())
+	g.Ω(entries).Should(gomega.Equal([]map[string]any{updated, org}))
+}
+
+func TestValid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(Valid(jane)).To(gomega.BeNil())
+	g.Expect(Valid(org)).To(gomega.BeNil())
+	g.Expect(Valid(map[string]any{model.Type: model.PersonType, model.GivenName: "Anon", model.Id: ""})).ToNot(gomega.BeNil())
+	g.Expect(Valid(map[string]any{model.Type: "SoftwareApplication", model.Id: "https://example.org"})).ToNot(gomega.BeNil())
+}
+
+func TestParse(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	entries, err := Parse([]byte(`[{"@type": "Person", "email": "jane@example.com"}]`))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(entries).Should(gomega.HaveLen(1))
+
+	entries, err = Parse([]byte(`{
+		"@type": "SoftwareSourceCode",
+		"author": [{"@type": "Person", "email": "jane@example.com"}],
+		"maintainer": {"@type": "Organization", "@id": "https://ror.org/05dxps055"},
+		"name": "project"
+	}`))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(entries).Should(gomega.HaveLen(2))
+
+	_, err = Parse([]byte(`"nope"`))
+	g.Expect(err).ToNot(gomega.BeNil())
+}
@@ -0,0 +1,134 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	Major = "major"
+	Minor = "minor"
+	Patch = "patch"
+
+	// the date format accepted by the #ValidDate definition of the schema
+	DateFormat = "2006-01-02"
+)
+
+// see: https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
+var semverRegexp = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// Version is a semantic version (https://semver.org), optionally prefixed with "v"
+type Version struct {
+	Prefix     string
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Build      string
+}
+
+func Parse(version string) (*Version, error) {
+	matches := semverRegexp.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid semantic version: %s, see: https://semver.org", version)
+	}
+	major, _ := strconv.Atoi(matches[2])
+	minor, _ := strconv.Atoi(matches[3])
+	patch, _ := strconv.Atoi(matches[4])
+	return &Version{
+		Prefix:     matches[1],
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		Prerelease: matches[5],
+		Build:      matches[6],
+	}, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// returns the next version. The spec is either "major", "minor" or "patch" to bump the
+// current version, or an explicit semantic version.
+func Bump(current string, spec string) (string, error) {
+	switch spec {
+	case Major, Minor, Patch:
+	default:
+		next, err := Parse(spec)
+		if err != nil {
+			return "", err
+		}
+		return next.String(), nil
+	}
+
+	if current == "" {
+		return "", fmt.Errorf("unable to bump the %s version, the current version is not set", spec)
+	}
+	v, err := Parse(current)
+	if err != nil {
+		return "", err
+	}
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch spec {
+	case Major:
+		// 2.0.0-rc.1 is released as 2.0.0
+		if v.Prerelease == "" || v.Minor != 0 || v.Patch != 0 {
+			next.Major++
+			next.Minor = 0
+			next.Patch = 0
+		}
+	case Minor:
+		if v.Prerelease == "" || v.Patch != 0 {
+			next.Minor++
+			next.Patch = 0
+		}
+	case Patch:
+		if v.Prerelease == "" {
+			next.Patch++
+		}
+	}
+	return next.String(), nil
+}
+
+var headingRegexp = regexp.MustCompile(`^##\s+\[?([^\]\s]+)\]?`)
+
+// returns the notes of the given version from a changelog in the Keep a Changelog
+// (https://keepachangelog.com) format, i.e., the content below a "## [1.2.0] - 2024-01-31"
+// heading up to the next "## " heading.
+func ChangelogSection(changelog string, version string) (string, error) {
+	want := strings.TrimPrefix(version, "v")
+	lines := strings.Split(strings.ReplaceAll(changelog, "\r\n", "\n"), "\n")
+	start := -1
+	for i, line := range lines {
+		matches := headingRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		if start >= 0 {
+			return strings.TrimSpace(strings.Join(lines[start:i], "\n")), nil
+		}
+		if strings.TrimPrefix(matches[1], "v") == want {
+			start = i + 1
+		}
+	}
+	if start < 0 {
+		return "", fmt.Errorf("no section for version %s found in the changelog", version)
+	}
+	// the last section, drop any trailing link reference definitions, e.g. "[1.2.0]: https://..."
+	section := lines[start:]
+	end := len(section)
+	for end > 0 && (strings.TrimSpace(section[end-1]) == "" || strings.HasPrefix(section[end-1], "[")) {
+		end--
+	}
+	return strings.TrimSpace(strings.Join(section[:end], "\n")), nil
+}
@@ -0,0 +1,89 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestBump(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		current  string
+		spec     string
+		expected string
+	}{
+		{"1.2.3", Major, "2.0.0"},
+		{"1.2.3", Minor, "1.3.0"},
+		{"1.2.3", Patch, "1.2.4"},
+		{"v0.9.1", Minor, "v0.10.0"},
+		{"1.2.3-beta.1+build.5", Patch, "1.2.3"},
+		{"2.0.0-rc.1", Major, "2.0.0"},
+		{"1.3.0-rc.1", Minor, "1.3.0"},
+		{"1.2.3", "3.0.0-alpha", "3.0.0-alpha"},
+		{"", "0.1.0", "0.1.0"},
+	}
+	for _, test := range tests {
+		actual, err := Bump(test.current, test.spec)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		g.Ω(actual).Should(gomega.Equal(test.expected), test.current+" "+test.spec)
+	}
+}
+
+func TestBumpErrors(t *testing.T) {
+	for _, test := range [][]string{{"", Patch}, {"1.2", Minor}, {"1.2.3", "1.2"}, {"1.2.3", "01.2.3"}} {
+		_, err := Bump(test[0], test[1])
+		if err == nil {
+			t.Errorf("Expected error for %v", test)
+		}
+	}
+}
+
+const changelog = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+
+- Something new
+
+## [1.1.0] - 2024-02-01
+
+### Added
+
+- Feature A
+- Feature B
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Initial release
+
+[unreleased]: https://github.com/org/project/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/org/project/compare/v1.0.0...v1.1.0
+[1.0.0]: https://github.com/org/project/releases/tag/v1.0.0
+`
+
+func TestChangelogSection(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	section, err := ChangelogSection(changelog, "v1.1.0")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(section).Should(gomega.Equal("### Added\n\n- Feature A\n- Feature B"))
+
+	// the last section
+	section, err = ChangelogSection(changelog, "1.0.0")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(section).Should(gomega.Equal("### Added\n\n- Initial release"))
+
+	_, err = ChangelogSection(changelog, "2.0.0")
+	g.Expect(err).ToNot(gomega.BeNil())
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/utils"
+)
+
+// Inferred holds the properties inferred from a local git repository. Values which
+// could not be inferred are empty.
+type Inferred struct {
+	CodeRepository        string
+	IssueTracker          string
+	ContinuousIntegration []string
+}
+
+// Forge is a known code hosting service
+type Forge struct {
+	Host string
+	// the path of the issue tracker relative to the repository URL
+	IssuesPath string
+	// the name of the host in CircleCI project URLs, empty when not supported
+	CircleCI string
+}
+
+var Forges = []Forge{
+	{Host: "github.com", IssuesPath: "/issues", CircleCI: "github"},
+	{Host: "gitlab.com", IssuesPath: "/-/issues", CircleCI: "gitlab"},
+	{Host: "bitbucket.org", IssuesPath: "/issues", CircleCI: "bitbucket"},
+	{Host: "codeberg.org", IssuesPath: "/issues"},
+}
+
+func forgeOf(repository string) *Forge {
+	u, err := url.Parse(repository)
+	if err != nil {
+		return nil
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	for _, forge := range Forges {
+		if forge.Host == host {
+			return &forge
+		}
+	}
+	return nil
+}
+
+// returns the code repository of a Go module hosted on a known forge, e.g.,
+// "https://github.com/spf13/cobra" for "github.com/spf13/cobra/v2"
+func OfGoModule(path string) string {
+	if name, ok := strings.CutPrefix(path, "golang.org/x/"); ok {
+		return "https://go.googlesource.com/" + strings.Split(name, "/")[0]
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	for _, forge := range Forges {
+		if parts[0] == forge.Host {
+			return "https://" + strings.Join(parts[:3], "/")
+		}
+	}
+	return ""
+}
+
+// infers the code repository from the git remote of the repository containing dir,
+// the issue tracker when the remote is on a known forge, and the continuous
+// integration URLs from the CI configurations found in the repository
+func Infer(dir string) (*Inferred, error) {
+	root, err := utils.FindGitRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := utils.GitRemoteURL(root)
+	if err != nil {
+		return nil, err
+	}
+	inferred := &Inferred{CodeRepository: utils.NormalizeRepositoryURL(remote)}
+	forge := forgeOf(inferred.CodeRepository)
+	if forge != nil {
+		inferred.IssueTracker = inferred.CodeRepository + forge.IssuesPath
+	}
+	inferred.ContinuousIntegration = continuousIntegration(root, inferred.CodeRepository, forge)
+	return inferred, nil
+}
+
+// returns the URLs of the CI pipelines configured in the repository
+func continuousIntegration(root string, repository string, forge *Forge) []string {
+	urls := []string{}
+
+	// GitHub Actions, one URL per workflow
+	workflows := []string{}
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, _ := filepath.Glob(filepath.Join(root, ".github", "workflows", pattern))
+		workflows = append(workflows, matches...)
+	}
+	sort.Strings(workflows)
+	for _, workflow := range workflows {
+		urls = append(urls, repository+"/actions/workflows/"+filepath.Base(workflow))
+	}
+
+	if exists(filepath.Join(root, ".gitlab-ci.yml")) {
+		urls = append(urls, repository+"/-/pipelines")
+	}
+
+	if exists(filepath.Join(root, ".circleci", "config.yml")) && forge != nil && forge.CircleCI != "" {
+		u, err := url.Parse(repository)
+		if err == nil {
+			urls = append(urls, "https://app.circleci.com/pipelines/"+forge.CircleCI+u.Path)
+		}
+	}
+	return urls
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
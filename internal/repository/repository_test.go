@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func repo(t *testing.T, remote string, files ...string) string {
+	dir := t.TempDir()
+	os.Mkdir(filepath.Join(dir, ".git"), 0755)
+	os.WriteFile(filepath.Join(dir, ".git", "config"), []byte("[remote \"origin\"]\n\turl = "+remote+"\n"), 0644)
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte{}, 0644)
+	}
+	return dir
+}
+
+func TestInferGitHub(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dir := repo(t, "git@github.com:org/project.git", ".github/workflows/test.yml", ".github/workflows/release.yaml", ".circleci/config.yml")
+	inferred, err := Infer(dir)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*inferred).Should(gomega.Equal(Inferred{
+		CodeRepository: "https://github.com/org/project",
+		IssueTracker:   "https://github.com/org/project/issues",
+		ContinuousIntegration: []string{
+			"https://github.com/org/project/actions/workflows/release.yaml",
+			"https://github.com/org/project/actions/workflows/test.yml",
+			"https://app.circleci.com/pipelines/github/org/project",
+		},
+	}))
+}
+
+func TestInferGitLab(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dir := repo(t, "ssh://git@gitlab.com/group/sub/project.git", ".gitlab-ci.yml")
+	os.MkdirAll(filepath.Join(dir, "src"), 0755)
+	inferred, err := Infer(filepath.Join(dir, "src"))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*inferred).Should(gomega.Equal(Inferred{
+		CodeRepository:        "https://gitlab.com/group/sub/project",
+		IssueTracker:          "https://gitlab.com/group/sub/project/-/issues",
+		ContinuousIntegration: []string{"https://gitlab.com/group/sub/project/-/pipelines"},
+	}))
+}
+
+func TestInferUnknownForge(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dir := repo(t, "https://git.example.org/project.git", ".circleci/config.yml")
+	inferred, err := Infer(dir)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*inferred).Should(gomega.Equal(Inferred{
+		CodeRepository:        "https://git.example.org/project",
+		ContinuousIntegration: []string{},
+	}))
+
+	_, err = Infer(t.TempDir())
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestOfGoModule(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Ω(OfGoModule("github.com/spf13/cobra/v2")).Should(gomega.Equal("https://github.com/spf13/cobra"))
+	g.Ω(OfGoModule("golang.org/x/mod/semver")).Should(gomega.Equal("https://go.googlesource.com/mod"))
+	g.Ω(OfGoModule("cuelang.org/go")).Should(gomega.BeEmpty())
+	g.Ω(OfGoModule("example.org/org/project")).Should(gomega.BeEmpty())
+}
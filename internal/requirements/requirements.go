@@ -0,0 +1,427 @@
+package requirements
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/repository"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/ohler55/ojg/oj"
+)
+
+// Requirement is a direct dependency read from a lockfile
+type Requirement struct {
+	Name           string
+	Version        string
+	CodeRepository string
+	// whether the dependency is only needed to develop or test the project
+	Dev bool
+}
+
+// returns the codemeta entry of the requirement, a SoftwareSourceCode when its code
+// repository is known and a SoftwareApplication otherwise
+func (r Requirement) Entry() map[string]any {
+	entry := map[string]any{
+		model.Type: model.SoftwareApplicationType,
+		model.Name: r.Name,
+	}
+	if r.Version != "" {
+		entry[model.Version] = r.Version
+	}
+	if r.CodeRepository != "" {
+		entry[model.Type] = model.SoftwareSourceCodeType
+		entry[model.CodeRepository] = r.CodeRepository
+	}
+	return entry
+}
+
+// Lockfile reads the direct dependencies of a project from a lockfile. The directory of
+// the lockfile is passed to read the manifest next to it, e.g., the go.mod of a go.sum.
+type Lockfile struct {
+	FileName string
+	Read     func(dir string, bytes []byte) ([]Requirement, error)
+}
+
+var Lockfiles = []Lockfile{
+	{FileName: "go.sum", Read: readGoSum},
+	{FileName: "package-lock.json", Read: readPackageLock},
+	{FileName: "requirements.txt", Read: readRequirementsTxt},
+	{FileName: "Cargo.lock", Read: readCargoLock},
+}
+
+func FileNames() []string {
+	names := make([]string, len(Lockfiles))
+	for i, lockfile := range Lockfiles {
+		names[i] = lockfile.FileName
+	}
+	return names
+}
+
+// returns the lockfile of the file name. Any *requirements*.txt file, e.g.,
+// requirements-dev.txt, is read as a requirements.txt file.
+func lockfileOf(name string) (*Lockfile, error) {
+	if strings.Contains(name, "requirements") && strings.HasSuffix(name, ".txt") {
+		name = "requirements.txt"
+	}
+	for _, lockfile := range Lockfiles {
+		if lockfile.FileName == name {
+			return &lockfile, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported lockfile: %s, must be one of: %s", name, strings.Join(FileNames(), ", "))
+}
+
+// reads the direct dependencies from the lockfile at path, sorted by name
+func Read(path string) ([]Requirement, error) {
+	lockfile, err := lockfileOf(filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %s", path, err.Error())
+	}
+	requirements, err := lockfile.Read(filepath.Dir(path), bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", path, err.Error())
+	}
+	// every requirement of, e.g., requirements-dev.txt or test-requirements.txt is a
+	// development requirement
+	if name := strings.ToLower(filepath.Base(path)); lockfile.FileName == "requirements.txt" && (strings.Contains(name, "dev") || strings.Contains(name, "test")) {
+		for i := range requirements {
+			requirements[i].Dev = true
+		}
+	}
+	sort.SliceStable(requirements, func(i, j int) bool {
+		return requirements[i].Name < requirements[j].Name
+	})
+	return requirements, nil
+}
+
+// go.sum lists every module of the build. The direct dependencies are the requirements
+// of the go.mod next to it which are not marked "// indirect", when there is no go.mod
+// every module is returned.
+func readGoSum(dir string, bytes []byte) ([]Requirement, error) {
+	versions := map[string]string{}
+	for i, line := range strings.Split(string(bytes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid line %d", i+1)
+		}
+		path, version := fields[0], strings.TrimSuffix(fields[1], "/go.mod")
+		// without a go.mod the last listed version is used
+		versions[path] = version
+	}
+
+	direct, err := goModRequires(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	if direct == nil {
+		direct = versions
+	}
+	requirements := []Requirement{}
+	for path, version := range direct {
+		requirements = append(requirements, Requirement{Name: path, Version: version, CodeRepository: repository.OfGoModule(path)})
+	}
+	return requirements, nil
+}
+
+// returns the direct requirements of the go.mod file, nil when it does not exist
+func goModRequires(path string) (map[string]string, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	requires := map[string]string{}
+	block := false
+	for _, line := range strings.Split(string(bytes), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "require (":
+			block = true
+			continue
+		case block && line == ")":
+			block = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !block:
+			continue
+		}
+		if strings.HasSuffix(line, "// indirect") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && !strings.HasPrefix(fields[0], "//") {
+			requires[fields[0]] = fields[1]
+		}
+	}
+	return requires, nil
+}
+
+// reads the dependencies and devDependencies of the root package of a lockfileVersion 2
+// or 3 package-lock.json, the code repository is read from the installed package.json
+func readPackageLock(dir string, bytes []byte) ([]Requirement, error) {
+	var lock map[string]any
+	err := oj.Unmarshal(bytes, &lock)
+	if err != nil {
+		return nil, err
+	}
+	packages, ok := lock["packages"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("lockfileVersion %v is not supported, run \"npm install\" to upgrade it", lock["lockfileVersion"])
+	}
+	root, _ := packages[""].(map[string]any)
+
+	requirements := []Requirement{}
+	add := func(key string, dev bool) {
+		dependencies, _ := root[key].(map[string]any)
+		for name := range dependencies {
+			requirement := Requirement{Name: name, Dev: dev}
+			if installed, ok := packages["node_modules/"+name].(map[string]any); ok {
+				requirement.Version, _ = installed["version"].(string)
+			}
+			requirement.CodeRepository = npmRepository(filepath.Join(dir, "node_modules", name, "package.json"))
+			requirements = append(requirements, requirement)
+		}
+	}
+	add("dependencies", false)
+	add("optionalDependencies", false)
+	add("devDependencies", true)
+	return requirements, nil
+}
+
+// returns the repository of an installed npm package, if any
+func npmRepository(path string) string {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var pkg map[string]any
+	if oj.Unmarshal(bytes, &pkg) != nil {
+		return ""
+	}
+	var url string
+	switch repository := pkg["repository"].(type) {
+	case string:
+		url = repository
+	case map[string]any:
+		url, _ = repository["url"].(string)
+	}
+	if url == "" || !strings.Contains(url, "/") {
+		// e.g., the "github:user/repo" shorthand without a host is not resolved
+		return ""
+	}
+	return utils.NormalizeRepositoryURL(url)
+}
+
+// reads the requirements of a pip requirements file, only pinned ("==") versions are kept
+func readRequirementsTxt(dir string, bytes []byte) ([]Requirement, error) {
+	requirements := []Requirement{}
+	for _, line := range strings.Split(string(bytes), "\n") {
+		line, _, _ = strings.Cut(line, " #")
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// editable and direct VCS requirements, e.g.: -e git+https://github.com/org/repo.git@v1#egg=name
+		if editable, ok := strings.CutPrefix(line, "-e "); ok {
+			line = strings.TrimSpace(editable)
+		}
+		if strings.HasPrefix(line, "-") {
+			// other options, e.g., -r other.txt or --index-url
+			continue
+		}
+		if strings.HasPrefix(line, "git+") {
+			url, fragment, _ := strings.Cut(line, "#")
+			_, name, _ := strings.Cut(fragment, "egg=")
+			url, version, _ := strings.Cut(strings.TrimPrefix(url, "git+"), ".git@")
+			if name == "" {
+				continue
+			}
+			requirements = append(requirements, Requirement{Name: name, Version: version, CodeRepository: utils.NormalizeRepositoryURL(url)})
+			continue
+		}
+		// drop environment markers and extras, e.g.: name[extra]==1.0; python_version < "3.8"
+		line, _, _ = strings.Cut(line, ";")
+		name := line
+		version := ""
+		if index := strings.IndexAny(line, "=<>!~ "); index >= 0 {
+			name = line[:index]
+			if pinned, ok := strings.CutPrefix(strings.TrimSpace(line[index:]), "=="); ok {
+				version = strings.TrimSpace(strings.TrimPrefix(pinned, "="))
+			}
+		}
+		name, _, _ = strings.Cut(name, "[")
+		requirements = append(requirements, Requirement{Name: strings.TrimSpace(name), Version: version})
+	}
+	return requirements, nil
+}
+
+// a [[package]] of a Cargo.lock file
+type cargoPackage struct {
+	name         string
+	version      string
+	source       string
+	dependencies []string
+}
+
+// Cargo.lock lists every package of the build. The direct dependencies are the
+// dependencies of the workspace packages, i.e., the packages without a source. The
+// development dependencies are read from the [dev-dependencies] of the Cargo.toml next
+// to it.
+func readCargoLock(dir string, bytes []byte) ([]Requirement, error) {
+	packages, err := parseCargoLock(string(bytes))
+	if err != nil {
+		return nil, err
+	}
+	dev := cargoDevDependencies(filepath.Join(dir, "Cargo.toml"))
+
+	workspace := map[string]bool{}
+	for _, pkg := range packages {
+		if pkg.source == "" {
+			workspace[pkg.name] = true
+		}
+	}
+	seen := map[string]bool{}
+	requirements := []Requirement{}
+	for _, pkg := range packages {
+		if pkg.source != "" {
+			continue
+		}
+		for _, dependency := range pkg.dependencies {
+			name, version, source := parseCargoDependency(dependency)
+			if workspace[name] || seen[dependency] {
+				continue
+			}
+			seen[dependency] = true
+			requirement := Requirement{Name: name, Version: version, Dev: dev[name]}
+			for _, locked := range packages {
+				if locked.name == name && (version == "" || locked.version == version) && (source == "" || locked.source == source) {
+					requirement.Version = locked.version
+					requirement.CodeRepository = cargoRepository(locked.source)
+					break
+				}
+			}
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements, nil
+}
+
+// parses a dependency of a [[package]], either "name", "name version" when several versions
+// are locked or "name version (source)" when the version is locked from several sources
+func parseCargoDependency(dependency string) (string, string, string) {
+	name, rest, _ := strings.Cut(dependency, " ")
+	version, source, _ := strings.Cut(rest, " ")
+	source = strings.TrimSuffix(strings.TrimPrefix(source, "("), ")")
+	return name, version, source
+}
+
+// returns the repository of a git source, e.g.,
+// "git+https://github.com/org/repo?branch=main#0123abcd"
+func cargoRepository(source string) string {
+	url, ok := strings.CutPrefix(source, "git+")
+	if !ok {
+		return ""
+	}
+	url, _, _ = strings.Cut(url, "#")
+	url, _, _ = strings.Cut(url, "?")
+	return utils.NormalizeRepositoryURL(url)
+}
+
+// parses the [[package]] tables of a Cargo.lock file
+func parseCargoLock(s string) ([]cargoPackage, error) {
+	packages := []cargoPackage{}
+	var current *cargoPackage
+	inDependencies := false
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if inDependencies {
+			if line == "]" {
+				inDependencies = false
+				continue
+			}
+			current.dependencies = append(current.dependencies, strings.Trim(line, `",`))
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			if line == "[[package]]" {
+				packages = append(packages, cargoPackage{})
+				current = &packages[len(packages)-1]
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %d", i+1)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		switch key {
+		case "name":
+			current.name = strings.Trim(value, `"`)
+		case "version":
+			current.version = strings.Trim(value, `"`)
+		case "source":
+			current.source = strings.Trim(value, `"`)
+		case "dependencies":
+			if value == "[" {
+				inDependencies = true
+				continue
+			}
+			for _, dependency := range strings.Split(strings.Trim(value, "[]"), ",") {
+				if dependency = strings.Trim(strings.TrimSpace(dependency), `"`); dependency != "" {
+					current.dependencies = append(current.dependencies, dependency)
+				}
+			}
+		}
+	}
+	return packages, nil
+}
+
+// returns the names of the [dev-dependencies] of the Cargo.toml file, if any
+func cargoDevDependencies(path string) map[string]bool {
+	dev := map[string]bool{}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return dev
+	}
+	table := ""
+	for _, line := range strings.Split(string(bytes), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			table = strings.Trim(line, "[] ")
+			// e.g., [dev-dependencies.serde]
+			if name, ok := strings.CutPrefix(table, "dev-dependencies."); ok {
+				dev[strings.Trim(name, `"`)] = true
+			}
+			continue
+		}
+		if table != "dev-dependencies" {
+			continue
+		}
+		if key, _, ok := strings.Cut(line, "="); ok && !strings.HasPrefix(line, "#") {
+			dev[strings.Trim(strings.TrimSpace(key), `"`)] = true
+		}
+	}
+	return dev
+}
@@ -0,0 +1,206 @@
+package requirements
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/onsi/gomega"
+)
+
+func write(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err = os.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return path
+}
+
+func TestReadGoSum(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dir := t.TempDir()
+	write(t, dir, "go.mod", `module example.com/project
+
+go 1.21
+
+require github.com/spf13/cobra v1.8.0
+
+require (
+	golang.org/x/exp v0.0.0-20231006140011-7918f672742d
+	github.com/spf13/pflag v1.0.5 // indirect
+)
+`)
+	path := write(t, dir, "go.sum", `github.com/spf13/cobra v1.8.0 h1:7aJaZx1B85qltLMc546zn58BqmfxsnqjCIBgHiG+IRM=
+github.com/spf13/cobra v1.8.0/go.mod h1:WXLWApfZ71AjXPya3WOlMsY9yMs7YeiHhFVlvLyhcho=
+github.com/spf13/pflag v1.0.5 h1:iy+VFUOCP1a+8yFto/drg2CJ5u0yRoB7fZw3DKv/JXA=
+golang.org/x/exp v0.0.0-20231006140011-7918f672742d h1:jtJma62tbqLibJ5sFQz8bKtEM8rJBtfilJ2qTU199MI=
+`)
+
+	requirements, err := Read(path)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(requirements).Should(gomega.Equal([]Requirement{
+		{Name: "github.com/spf13/cobra", Version: "v1.8.0", CodeRepository: "https://github.com/spf13/cobra"},
+		{Name: "golang.org/x/exp", Version: "v0.0.0-20231006140011-7918f672742d", CodeRepository: "https://go.googlesource.com/exp"},
+	}))
+}
+
+func TestReadPackageLock(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dir := t.TempDir()
+	path := write(t, dir, "package-lock.json", `{
+		"name": "project",
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "project", "dependencies": {"left-pad": "^1.3.0"}, "devDependencies": {"jest": "^29.0.0"}},
+			"node_modules/left-pad": {"version": "1.3.0"},
+			"node_modules/jest": {"version": "29.7.0", "dev": true},
+			"node_modules/jest-cli": {"version": "29.7.0", "dev": true}
+		}
+	}`)
+	write(t, dir, "node_modules/jest/package.json", `{"name": "jest", "repository": {"type": "git", "url": "https://github.com/jestjs/jest.git"}}`)
+
+	requirements, err := Read(path)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(requirements).Should(gomega.Equal([]Requirement{
+		{Name: "jest", Version: "29.7.0", CodeRepository: "https://github.com/jestjs/jest", Dev: true},
+		{Name: "left-pad", Version: "1.3.0"},
+	}))
+
+	// lockfileVersion 1 has no packages
+	path = write(t, dir, "v1/package-lock.json", `{"name": "project", "lockfileVersion": 1, "dependencies": {}}`)
+	_, err = Read(path)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestReadRequirementsTxt(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dir := t.TempDir()
+	content := `# comment
+requests==2.31.0
+numpy>=1.24 ; python_version >= "3.9"
+pandas[excel]==2.1.0  # pinned
+-r base.txt
+-e git+https://github.com/org/tool.git@v1.0#egg=tool
+`
+	requirements, err := Read(write(t, dir, "requirements.txt", content))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(requirements).Should(gomega.Equal([]Requirement{
+		{Name: "numpy"},
+		{Name: "pandas", Version: "2.1.0"},
+		{Name: "requests", Version: "2.31.0"},
+		{Name: "tool", Version: "v1.0", CodeRepository: "https://github.com/org/tool"},
+	}))
+
+	requirements, err = Read(write(t, dir, "requirements-dev.txt", "pytest==8.0.0\n"))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(requirements).Should(gomega.Equal([]Requirement{{Name: "pytest", Version: "8.0.0", Dev: true}}))
+}
+
+func TestReadCargoLock(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	dir := t.TempDir()
+	write(t, dir, "Cargo.toml", `[package]
+name = "project"
+
+[dependencies]
+serde = "1"
+tool = { git = "https://github.com/org/tool" }
+
+[dev-dependencies]
+insta = "1"
+`)
+	path := write(t, dir, "Cargo.lock", `# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "insta"
+version = "1.34.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "project"
+version = "0.1.0"
+dependencies = [
+ "insta",
+ "rand 0.8.5 (git+https://github.com/rust-random/rand?branch=master#4567cdef)",
+ "serde 1.0.193",
+ "tool",
+]
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "git+https://github.com/rust-random/rand?branch=master#4567cdef"
+
+[[package]]
+name = "serde"
+version = "0.9.15"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "serde"
+version = "1.0.193"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "tool"
+version = "0.2.0"
+source = "git+https://github.com/org/tool?branch=main#0123abcd"
+`)
+
+	requirements, err := Read(path)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(requirements).Should(gomega.Equal([]Requirement{
+		{Name: "insta", Version: "1.34.0", Dev: true},
+		{Name: "rand", Version: "0.8.5", CodeRepository: "https://github.com/rust-random/rand"},
+		{Name: "serde", Version: "1.0.193"},
+		{Name: "tool", Version: "0.2.0", CodeRepository: "https://github.com/org/tool"},
+	}))
+}
+
+func TestRead(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	_, err := Read(write(t, t.TempDir(), "yarn.lock", ""))
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestEntry(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Ω(Requirement{Name: "left-pad", Version: "1.3.0"}.Entry()).Should(gomega.Equal(map[string]any{
+		model.Type:    model.SoftwareApplicationType,
+		model.Name:    "left-pad",
+		model.Version: "1.3.0",
+	}))
+	g.Ω(Requirement{Name: "tool", CodeRepository: "https://github.com/org/tool"}.Entry()).Should(gomega.Equal(map[string]any{
+		model.Type:           model.SoftwareSourceCodeType,
+		model.Name:           "tool",
+		model.CodeRepository: "https://github.com/org/tool",
+	}))
+}
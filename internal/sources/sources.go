@@ -0,0 +1,341 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/languages"
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/cacoco/codemetagenerator/internal/repository"
+	"github.com/cacoco/codemetagenerator/internal/utils"
+	"github.com/ohler55/ojg/oj"
+)
+
+// Source derives codemeta properties from a project manifest file
+type Source struct {
+	FileName string
+	Derive   func(bytes []byte) (map[string]any, error)
+}
+
+var Sources = []Source{
+	{FileName: "package.json", Derive: derivePackageJSON},
+	{FileName: "Cargo.toml", Derive: deriveCargoToml},
+	{FileName: "pyproject.toml", Derive: derivePyprojectToml},
+	{FileName: "go.mod", Derive: deriveGoMod},
+}
+
+// the properties which hold lists of entries, e.g., of programming languages. A derived
+// entry is expected to be listed among the entries of a document instead of replacing them.
+var listed = map[string]func(entry any, derived any) bool{
+	model.ProgrammingLanguage: sameLanguage,
+	model.RuntimePlatform:     samePlatform,
+}
+
+func FileNames() []string {
+	names := make([]string, len(Sources))
+	for i, source := range Sources {
+		names[i] = source.FileName
+	}
+	return names
+}
+
+// derives codemeta properties from the manifests found in the directory. When names
+// is not empty only the named manifests are used and each one must exist. Returns the
+// derived properties and the manifests which were used.
+func Derive(dir string, names []string) (map[string]any, []string, error) {
+	selected := Sources
+	if len(names) > 0 {
+		selected = []Source{}
+		for _, name := range names {
+			found := false
+			for _, source := range Sources {
+				if source.FileName == name {
+					selected = append(selected, source)
+					found = true
+				}
+			}
+			if !found {
+				return nil, nil, fmt.Errorf("unsupported source: %s, must be one of: %s", name, strings.Join(FileNames(), ", "))
+			}
+		}
+	}
+
+	result := make(map[string]any)
+	used := []string{}
+	for _, source := range selected {
+		path := filepath.Join(dir, source.FileName)
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) && len(names) == 0 {
+				continue
+			}
+			return nil, nil, fmt.Errorf("unable to read %s: %s", path, err.Error())
+		}
+		derived, err := source.Derive(bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse %s: %s", path, err.Error())
+		}
+		// earlier sources take precedence
+		for key, value := range derived {
+			if _, ok := result[key]; !ok {
+				result[key] = value
+			}
+		}
+		used = append(used, source.FileName)
+	}
+	return result, used, nil
+}
+
+// returns the merge patch which brings the document up to date with the derived
+// properties. The derived entries of listed properties which the document does not list
+// yet are appended to its entries.
+func Patch(document map[string]any, derived map[string]any) map[string]any {
+	patch := make(map[string]any)
+	for key, value := range derived {
+		same, ok := listed[key]
+		if !ok {
+			patch[key] = value
+			continue
+		}
+		entries := entriesOf(document[key])
+		missing := false
+		for _, entry := range entriesOf(value) {
+			found := false
+			for _, existing := range entries {
+				if same(existing, entry) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				entries = append(entries, entry)
+				missing = true
+			}
+		}
+		if missing {
+			patch[key] = entries
+		}
+	}
+	return patch
+}
+
+// returns the entries of a property which holds either a single entry or a list
+func entriesOf(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return []any{}
+	case []any:
+		return append([]any{}, v...)
+	}
+	return []any{value}
+}
+
+// languages are the same by their name, ignoring case. A language may be given as text.
+func sameLanguage(entry any, derived any) bool {
+	nameOf := func(language any) string {
+		if m, ok := language.(map[string]any); ok {
+			language = m[model.Name]
+		}
+		s, _ := language.(string)
+		return strings.ToLower(s)
+	}
+	return nameOf(entry) != "" && nameOf(entry) == nameOf(derived)
+}
+
+// a platform is listed when an entry mentions it, ignoring case and spaces, e.g., "go1.21"
+// is listed by "go version go1.21" and "Go 1.21"
+func samePlatform(entry any, derived any) bool {
+	normalize := func(platform any) string {
+		s, _ := platform.(string)
+		return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	}
+	return normalize(derived) != "" && strings.Contains(normalize(entry), normalize(derived))
+}
+
+func licenseURL(id string) string {
+	return "https://spdx.org/licenses/" + id + ".html"
+}
+
+// only single SPDX license IDs are mapped, expressions like "MIT OR Apache-2.0" are skipped
+func putLicense(m map[string]any, license string) {
+	if license != "" && !strings.ContainsAny(license, " ()") {
+		m[model.License] = licenseURL(license)
+	}
+}
+
+func putString(m map[string]any, key string, value any) {
+	if s, ok := value.(string); ok && s != "" {
+		m[key] = s
+	}
+}
+
+// returns the value, or the "url" member when the value is an object
+func urlOf(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if url, ok := v["url"].(string); ok {
+			return url
+		}
+	}
+	return ""
+}
+
+func derivePackageJSON(bytes []byte) (map[string]any, error) {
+	var pkg map[string]any
+	err := oj.Unmarshal(bytes, &pkg)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]any)
+	putString(m, model.Version, pkg["version"])
+	putString(m, model.Description, pkg["description"])
+	putString(m, model.URL, pkg["homepage"])
+	if license, ok := pkg["license"].(string); ok {
+		putLicense(m, license)
+	}
+	if repository := urlOf(pkg["repository"]); repository != "" {
+		m[model.CodeRepository] = utils.NormalizeRepositoryURL(repository)
+	}
+	putString(m, model.IssueTracker, urlOf(pkg["bugs"]))
+	return m, nil
+}
+
+func deriveCargoToml(bytes []byte) (map[string]any, error) {
+	tables, err := parseToml(string(bytes))
+	if err != nil {
+		return nil, err
+	}
+	pkg := tables["package"]
+	m := make(map[string]any)
+	putString(m, model.Version, pkg["version"])
+	putString(m, model.Description, pkg["description"])
+	putString(m, model.URL, pkg["homepage"])
+	if license, ok := pkg["license"].(string); ok {
+		putLicense(m, license)
+	}
+	if repository, ok := pkg["repository"].(string); ok && repository != "" {
+		m[model.CodeRepository] = utils.NormalizeRepositoryURL(repository)
+	}
+	return m, nil
+}
+
+func derivePyprojectToml(bytes []byte) (map[string]any, error) {
+	tables, err := parseToml(string(bytes))
+	if err != nil {
+		return nil, err
+	}
+	project := tables["project"]
+	m := make(map[string]any)
+	putString(m, model.Version, project["version"])
+	putString(m, model.Description, project["description"])
+	if license, ok := project["license"].(string); ok {
+		putLicense(m, license)
+	}
+	for key, value := range tables["project.urls"] {
+		url, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "homepage":
+			m[model.URL] = url
+		case "repository", "source", "source code":
+			m[model.CodeRepository] = utils.NormalizeRepositoryURL(url)
+		case "issues", "bug tracker", "tracker":
+			m[model.IssueTracker] = url
+		}
+	}
+	return m, nil
+}
+
+// derives the code repository from the module path when the module is hosted on a known
+// forge, and Go as the programming language and the Go version of the go directive as the
+// runtime platform
+func deriveGoMod(bytes []byte) (map[string]any, error) {
+	m := make(map[string]any)
+	for _, line := range strings.Split(string(bytes), "\n") {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "module":
+			if url := repository.OfGoModule(strings.Trim(fields[1], `"`)); url != "" {
+				m[model.CodeRepository] = url
+			}
+		case "go":
+			for _, language := range languages.Languages {
+				if language.Name == "Go" {
+					m[model.ProgrammingLanguage] = []any{language.Entry()}
+				}
+			}
+			m[model.RuntimePlatform] = []any{"go" + fields[1]}
+		}
+	}
+	return m, nil
+}
+
+// parses the subset of TOML used by manifests: tables of string and string array values.
+// Any other values are ignored.
+func parseToml(s string) (map[string]map[string]any, error) {
+	tables := map[string]map[string]any{"": {}}
+	table := ""
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("invalid table header on line %d", i+1)
+			}
+			table = strings.Trim(line, "[] ")
+			if _, ok := tables[table]; !ok {
+				tables[table] = map[string]any{}
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			// e.g., the continuation of a multi-line array
+			continue
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'"):
+			if str, ok := tomlString(value); ok {
+				tables[table][key] = str
+			}
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			arr := []any{}
+			for _, elem := range strings.Split(strings.Trim(value, "[]"), ",") {
+				if str, ok := tomlString(strings.TrimSpace(elem)); ok {
+					arr = append(arr, str)
+				}
+			}
+			tables[table][key] = arr
+		}
+	}
+	return tables, nil
+}
+
+func tomlString(value string) (string, bool) {
+	if len(value) < 2 {
+		return "", false
+	}
+	quote := value[0]
+	if quote != '"' && quote != '\'' {
+		return "", false
+	}
+	end := strings.IndexByte(value[1:], quote)
+	if end < 0 {
+		return "", false
+	}
+	return value[1 : end+1], true
+}
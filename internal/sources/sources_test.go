@@ -0,0 +1,170 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/onsi/gomega"
+)
+
+func TestDerivePackageJSON(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	m, err := derivePackageJSON([]byte(`{
+		"name": "project",
+		"version": "1.2.3",
+		"description": "description",
+		"license": "MIT",
+		"homepage": "https://project.example",
+		"repository": {"type": "git", "url": "git+https://github.com/org/project.git"},
+		"bugs": {"url": "https://github.com/org/project/issues"}
+	}`))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(m).Should(gomega.Equal(map[string]any{
+		model.Version:        "1.2.3",
+		model.Description:    "description",
+		model.License:        "https://spdx.org/licenses/MIT.html",
+		model.URL:            "https://project.example",
+		model.CodeRepository: "https://github.com/org/project",
+		model.IssueTracker:   "https://github.com/org/project/issues",
+	}))
+}
+
+func TestDeriveCargoToml(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	m, err := deriveCargoToml([]byte(`
+# a comment
+[package]
+name = "project"
+version = "0.4.0"
+description = 'description'
+license = "MIT OR Apache-2.0"
+repository = "https://github.com/org/project"
+keywords = ["cli", "codemeta"]
+
+[dependencies]
+version = "not the package version"
+`))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(m).Should(gomega.Equal(map[string]any{
+		model.Version:        "0.4.0",
+		model.Description:    "description",
+		model.CodeRepository: "https://github.com/org/project",
+	}))
+}
+
+func TestDerivePyprojectToml(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	m, err := derivePyprojectToml([]byte(`
+[project]
+name = "project"
+version = "2.0.0"
+license = "BSD-3-Clause"
+
+[project.urls]
+Homepage = "https://project.example"
+Repository = "https://github.com/org/project.git"
+Issues = "https://github.com/org/project/issues"
+`))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(m).Should(gomega.Equal(map[string]any{
+		model.Version:        "2.0.0",
+		model.License:        "https://spdx.org/licenses/BSD-3-Clause.html",
+		model.URL:            "https://project.example",
+		model.CodeRepository: "https://github.com/org/project",
+		model.IssueTracker:   "https://github.com/org/project/issues",
+	}))
+}
+
+func TestDeriveGoMod(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	m, err := deriveGoMod([]byte(`// a comment
+module github.com/org/project/v2
+
+go 1.21.6
+
+require github.com/spf13/cobra v1.8.0 // go 1.20
+`))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(m).Should(gomega.Equal(map[string]any{
+		model.CodeRepository: "https://github.com/org/project",
+		model.ProgrammingLanguage: []any{map[string]any{
+			model.Type: model.ComputerLanguageType,
+			model.Name: "Go",
+			model.URL:  "https://go.dev",
+		}},
+		model.RuntimePlatform: []any{"go1.21.6"},
+	}))
+
+	// modules which are not hosted on a known forge have no code repository
+	m, err = deriveGoMod([]byte("module example.org/project\n"))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(m).Should(gomega.BeEmpty())
+}
+
+func TestPatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	derived, err := deriveGoMod([]byte("module github.com/org/project\n\ngo 1.21\n"))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// listed entries are kept
+	document := map[string]any{
+		model.CodeRepository:      "https://github.com/org/other",
+		model.ProgrammingLanguage: map[string]any{model.Type: model.ComputerLanguageType, model.Name: "go"},
+		model.RuntimePlatform:     "go version go1.21",
+	}
+	g.Ω(Patch(document, derived)).Should(gomega.Equal(map[string]any{
+		model.CodeRepository: "https://github.com/org/project",
+	}))
+
+	// missing entries are appended to the entries of the document
+	document = map[string]any{
+		model.ProgrammingLanguage: []any{"CUE"},
+		model.RuntimePlatform:     []any{"Go 1.20"},
+	}
+	g.Ω(Patch(document, derived)).Should(gomega.Equal(map[string]any{
+		model.CodeRepository:      "https://github.com/org/project",
+		model.ProgrammingLanguage: []any{"CUE", derived[model.ProgrammingLanguage].([]any)[0]},
+		model.RuntimePlatform:     []any{"Go 1.20", "go1.21"},
+	}))
+}
+
+func TestDerive(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	os.WriteFile(filepath.Join(temp, "package.json"), []byte(`{"version": "1.0.0"}`), 0644)
+
+	m, used, err := Derive(temp, []string{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(used).Should(gomega.Equal([]string{"package.json"}))
+	g.Ω(m).Should(gomega.Equal(map[string]any{model.Version: "1.0.0"}))
+
+	// named sources must exist
+	_, _, err = Derive(temp, []string{"Cargo.toml"})
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	// and be supported
+	_, _, err = Derive(temp, []string{"setup.py"})
+	g.Expect(err).ToNot(gomega.BeNil())
+}
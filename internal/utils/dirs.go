@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// the environment variable of the directory containing the .codemetagenerator directory
+	HomeEnv = "CODEMETAGENERATOR_HOME"
+
+	xdgConfigHome    = "XDG_CONFIG_HOME"
+	xdgCacheHome     = "XDG_CACHE_HOME"
+	xdgStateHome     = "XDG_STATE_HOME"
+	xdgDirectoryName = "codemetagenerator"
+)
+
+// the directories of the configuration files (config.yaml, the profile and the address
+// book), the cached SPDX licenses and the state (the drafts), set with Dirs.Use. When
+// unset, the .codemetagenerator directory of the basedir is used.
+var ConfigDir, CacheDir, StateDir string
+
+func dirOrHomeDir(dir string, basedir string) string {
+	if dir != "" {
+		return dir
+	}
+	return GetHomeDir(basedir)
+}
+
+func GetConfigDir(basedir string) string {
+	return dirOrHomeDir(ConfigDir, basedir)
+}
+
+func GetCacheDir(basedir string) string {
+	return dirOrHomeDir(CacheDir, basedir)
+}
+
+func GetStateDir(basedir string) string {
+	return dirOrHomeDir(StateDir, basedir)
+}
+
+// Dirs are the resolved configuration, cache and state directories
+type Dirs struct {
+	Config string
+	Cache  string
+	State  string
+	// the .codemetagenerator directory of the user home directory, which the files of an
+	// XDG directory were kept in before its XDG_* variable was set. Empty with --home.
+	Legacy string
+}
+
+// sets the package level directories
+func (d *Dirs) Use() {
+	ConfigDir = d.Config
+	CacheDir = d.Cache
+	StateDir = d.State
+}
+
+// returns the distinct directories
+func (d *Dirs) All() []string {
+	return lo.Uniq([]string{d.Config, d.Cache, d.State})
+}
+
+// returns the directories currently in use for the basedir
+func CurrentDirs(basedir string) *Dirs {
+	return &Dirs{Config: GetConfigDir(basedir), Cache: GetCacheDir(basedir), State: GetStateDir(basedir)}
+}
+
+// resolves the directories. When home is given, e.g., with the --home flag, or else set
+// with CODEMETAGENERATOR_HOME, all files are kept in its .codemetagenerator directory.
+// Otherwise each directory is the "codemetagenerator" directory of XDG_CONFIG_HOME,
+// XDG_CACHE_HOME or XDG_STATE_HOME when set, falling back to the .codemetagenerator
+// directory of the user home directory. Relative XDG directories are ignored, as
+// required by the XDG Base Directory Specification.
+func ResolveDirs(home string, lookupEnv func(string) (string, bool), userHomeDir func() (string, error)) (*Dirs, error) {
+	if home == "" {
+		home, _ = lookupEnv(HomeEnv)
+	}
+	if home != "" {
+		abs, err := filepath.Abs(home)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve the home directory %s: %s", home, err.Error())
+		}
+		dir := GetHomeDir(abs)
+		return &Dirs{Config: dir, Cache: dir, State: dir}, nil
+	}
+
+	var legacy string
+	homeDir, homeErr := userHomeDir()
+	if homeErr == nil && homeDir == "" {
+		homeErr = fmt.Errorf("$HOME is not defined")
+	}
+	if homeErr == nil {
+		legacy = GetHomeDir(homeDir)
+	}
+	resolve := func(name string) (string, error) {
+		if xdg, ok := lookupEnv(name); ok && filepath.IsAbs(xdg) {
+			return filepath.Join(xdg, xdgDirectoryName), nil
+		}
+		if homeErr != nil {
+			return "", fmt.Errorf("unable to resolve the codemetagenerator directory: %s. Pass --home or set %s, or set %s", homeErr.Error(), HomeEnv, name)
+		}
+		return legacy, nil
+	}
+	config, err := resolve(xdgConfigHome)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := resolve(xdgCacheHome)
+	if err != nil {
+		return nil, err
+	}
+	state, err := resolve(xdgStateHome)
+	if err != nil {
+		return nil, err
+	}
+	return &Dirs{Config: config, Cache: cache, State: state, Legacy: legacy}, nil
+}
+
+// moves the named files and directories of the legacy directory to the directory when it
+// does not have them yet, e.g., the configuration files after XDG_CONFIG_HOME has been
+// set, so that they are not left behind. Returns the moved paths.
+func MoveLegacyFiles(legacy string, dir string, names []string) ([]string, error) {
+	if legacy == "" || legacy == dir {
+		return nil, nil
+	}
+	moved := []string{}
+	for _, name := range names {
+		from := filepath.Join(legacy, name)
+		to := filepath.Join(dir, name)
+		if _, err := os.Stat(from); err != nil {
+			continue
+		}
+		if _, err := os.Stat(to); err == nil {
+			continue
+		}
+		err := os.MkdirAll(dir, 0755)
+		if err == nil {
+			err = os.Rename(from, to)
+		}
+		if err != nil {
+			return moved, fmt.Errorf("unable to move %s to %s: %s", from, to, err.Error())
+		}
+		moved = append(moved, from)
+	}
+	return moved, nil
+}
+
+// creates the configuration, cache and state directories of the basedir
+func MkHomeDir(basedir string) error {
+	for _, dir := range CurrentDirs(basedir).All() {
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return fmt.Errorf("unable to create codemetagenerator directory: %s", err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func userHome(dir string) func() (string, error) {
+	return func() (string, error) {
+		return dir, nil
+	}
+}
+
+func TestResolveDirs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// the legacy directory
+	dirs, err := ResolveDirs("", env(nil), userHome("/home/user"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(*dirs).Should(gomega.Equal(Dirs{Config: "/home/user/.codemetagenerator", Cache: "/home/user/.codemetagenerator", State: "/home/user/.codemetagenerator", Legacy: "/home/user/.codemetagenerator"}))
+	g.Ω(dirs.All()).Should(gomega.Equal([]string{"/home/user/.codemetagenerator"}))
+
+	// the XDG directories, a relative directory is ignored
+	dirs, err = ResolveDirs("", env(map[string]string{"XDG_CONFIG_HOME": "/xdg/config", "XDG_CACHE_HOME": "/xdg/cache", "XDG_STATE_HOME": "state"}), userHome("/home/user"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(*dirs).Should(gomega.Equal(Dirs{Config: "/xdg/config/codemetagenerator", Cache: "/xdg/cache/codemetagenerator", State: "/home/user/.codemetagenerator", Legacy: "/home/user/.codemetagenerator"}))
+
+	// the home directory overrides the XDG directories, the flag overrides the environment
+	xdg := map[string]string{"XDG_CONFIG_HOME": "/xdg/config", "XDG_CACHE_HOME": "/xdg/cache", "XDG_STATE_HOME": "/xdg/state"}
+	xdg[HomeEnv] = "/ci/job"
+	dirs, err = ResolveDirs("", env(xdg), userHome("/home/user"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(dirs.All()).Should(gomega.Equal([]string{"/ci/job/.codemetagenerator"}))
+	g.Ω(dirs.Legacy).Should(gomega.BeEmpty())
+	dirs, err = ResolveDirs("/flag", env(xdg), userHome("/home/user"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(dirs.All()).Should(gomega.Equal([]string{"/flag/.codemetagenerator"}))
+	dirs, err = ResolveDirs("relative", env(nil), userHome("/home/user"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(filepath.IsAbs(dirs.State)).To(gomega.BeTrue())
+}
+
+func TestResolveDirsWithoutHome(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	noHome := func() (string, error) {
+		return "", fmt.Errorf("$HOME is not defined")
+	}
+	_, err := ResolveDirs("", env(nil), noHome)
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("--home"))
+	_, err = ResolveDirs("", env(nil), userHome(""))
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	// all of the XDG directories are set
+	dirs, err := ResolveDirs("", env(map[string]string{"XDG_CONFIG_HOME": "/xdg/config", "XDG_CACHE_HOME": "/xdg/cache", "XDG_STATE_HOME": "/xdg/state"}), noHome)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(dirs.State).Should(gomega.Equal("/xdg/state/codemetagenerator"))
+}
+
+func TestUseDirs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	defer (&Dirs{}).Use()
+	temp := t.TempDir()
+	dirs := &Dirs{Config: filepath.Join(temp, "config"), Cache: filepath.Join(temp, "cache"), State: filepath.Join(temp, "state")}
+	dirs.Use()
+
+	g.Ω(GetInProgressFilePath("basedir")).Should(gomega.Equal(filepath.Join(temp, "state", "codemeta.inprogress.json")))
+	g.Ω(GetDraftsDir("basedir")).Should(gomega.Equal(filepath.Join(temp, "state", "drafts")))
+	g.Ω(GetLicensesFilePath("basedir")).Should(gomega.Equal(filepath.Join(temp, "cache", "spdx-licenses.json")))
+	g.Ω(GetProfileFilePath("basedir")).Should(gomega.Equal(filepath.Join(temp, "config", "profile.json")))
+
+	err := MkHomeDir("basedir")
+	g.Expect(err).To(gomega.BeNil())
+	for _, dir := range dirs.All() {
+		_, err := os.Stat(dir)
+		g.Expect(err).To(gomega.BeNil())
+	}
+}
+
+func TestMoveLegacyFiles(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	legacy := filepath.Join(t.TempDir(), ".codemetagenerator")
+	dir := filepath.Join(t.TempDir(), "codemetagenerator")
+	os.MkdirAll(filepath.Join(legacy, "drafts"), 0755)
+	os.WriteFile(filepath.Join(legacy, "config.yaml"), []byte("license: MIT\n"), 0644)
+	os.WriteFile(filepath.Join(legacy, "profile.json"), []byte("{}"), 0644)
+	os.WriteFile(filepath.Join(legacy, "spdx-licenses.json"), []byte("{}"), 0644)
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "profile.json"), []byte(`{"name": "new"}`), 0644)
+
+	moved, err := MoveLegacyFiles(legacy, dir, []string{"config.yaml", "profile.json", "people.json", "drafts"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(moved).Should(gomega.Equal([]string{filepath.Join(legacy, "config.yaml"), filepath.Join(legacy, "drafts")}))
+	bytes, err := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(string(bytes)).Should(gomega.Equal("license: MIT\n"))
+	_, err = os.Stat(filepath.Join(dir, "drafts"))
+	g.Expect(err).To(gomega.BeNil())
+	// an existing file is not replaced, the files which are not named are kept
+	bytes, _ = os.ReadFile(filepath.Join(dir, "profile.json"))
+	g.Ω(string(bytes)).Should(gomega.Equal(`{"name": "new"}`))
+	_, err = os.Stat(filepath.Join(legacy, "spdx-licenses.json"))
+	g.Expect(err).To(gomega.BeNil())
+
+	// nothing is moved when the directory is the legacy directory
+	moved, err = MoveLegacyFiles(legacy, legacy, []string{"spdx-licenses.json"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(moved).Should(gomega.BeEmpty())
+}
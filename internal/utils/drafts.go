@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// the default draft is stored at the original in-progress file location
+	DefaultDraftName = "default"
+
+	draftsDirectoryName  = "drafts"
+	draftFileSuffix      = ".inprogress.json"
+	currentDraftFileName = "current-draft"
+)
+
+// the files and directories of the state directory
+var StateFileNames = []string{inProgressFileName, draftsDirectoryName, currentDraftFileName}
+
+var validDraftName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+func ValidDraftName(name string) error {
+	if !validDraftName.MatchString(name) {
+		return fmt.Errorf("invalid draft name: %s, names may only contain letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+func GetDraftsDir(basedir string) string {
+	return GetStateDir(basedir) + "/" + draftsDirectoryName
+}
+
+func MkDraftsDir(basedir string) error {
+	err := os.MkdirAll(GetDraftsDir(basedir), 0755)
+	if err != nil {
+		return fmt.Errorf("unable to create codemetagenerator drafts directory: %s", err.Error())
+	}
+	return nil
+}
+
+// returns the in-progress file path for the named draft, the default draft
+// uses the original codemeta.inprogress.json location
+func GetDraftFilePath(basedir string, name string) string {
+	if name == "" || name == DefaultDraftName {
+		return GetInProgressFilePath(basedir)
+	}
+	return GetDraftsDir(basedir) + "/" + name + draftFileSuffix
+}
+
+func getCurrentDraftFilePath(basedir string) string {
+	return GetStateDir(basedir) + "/" + currentDraftFileName
+}
+
+// returns the name of the selected draft, or the default draft if none has been selected
+func GetCurrentDraft(basedir string) string {
+	bytes, err := os.ReadFile(getCurrentDraftFilePath(basedir))
+	if err != nil {
+		return DefaultDraftName
+	}
+	name := strings.TrimSpace(string(bytes))
+	if ValidDraftName(name) != nil {
+		return DefaultDraftName
+	}
+	return name
+}
+
+func SetCurrentDraft(basedir string, name string) error {
+	err := MkHomeDir(basedir)
+	if err != nil {
+		return err
+	}
+	return WriteFile(getCurrentDraftFilePath(basedir), []byte(name))
+}
+
+func DraftExists(basedir string, name string) bool {
+	_, err := os.Stat(GetDraftFilePath(basedir, name))
+	return err == nil
+}
+
+// lists the names of all drafts which have an in-progress file, sorted by name
+func ListDrafts(basedir string) ([]string, error) {
+	drafts := []string{}
+	if DraftExists(basedir, DefaultDraftName) {
+		drafts = append(drafts, DefaultDraftName)
+	}
+
+	entries, err := os.ReadDir(GetDraftsDir(basedir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return drafts, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), draftFileSuffix) {
+			continue
+		}
+		drafts = append(drafts, strings.TrimSuffix(entry.Name(), draftFileSuffix))
+	}
+	sort.Strings(drafts)
+	return drafts, nil
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestGetDraftFilePath(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Ω(GetDraftFilePath("basedir", "")).Should(gomega.Equal("basedir/.codemetagenerator/codemeta.inprogress.json"))
+	g.Ω(GetDraftFilePath("basedir", DefaultDraftName)).Should(gomega.Equal("basedir/.codemetagenerator/codemeta.inprogress.json"))
+	g.Ω(GetDraftFilePath("basedir", "project")).Should(gomega.Equal("basedir/.codemetagenerator/drafts/project.inprogress.json"))
+}
+
+func TestValidDraftName(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(ValidDraftName("project-1.2_a")).To(gomega.BeNil())
+	g.Expect(ValidDraftName("")).ToNot(gomega.BeNil())
+	g.Expect(ValidDraftName("../escape")).ToNot(gomega.BeNil())
+	g.Expect(ValidDraftName("with/slash")).ToNot(gomega.BeNil())
+}
+
+func TestCurrentDraft(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	// no selection falls back to the default draft
+	g.Ω(GetCurrentDraft(temp)).Should(gomega.Equal(DefaultDraftName))
+
+	err := SetCurrentDraft(temp, "project")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(GetCurrentDraft(temp)).Should(gomega.Equal("project"))
+}
+
+func TestListDrafts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	temp := t.TempDir()
+	drafts, err := ListDrafts(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(drafts).Should(gomega.BeEmpty())
+
+	err = MkDraftsDir(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, name := range []string{DefaultDraftName, "zeta", "alpha"} {
+		err = WriteJSON(GetDraftFilePath(temp, name), `{}`)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+
+	drafts, err = ListDrafts(temp)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(drafts).Should(gomega.Equal([]string{"alpha", DefaultDraftName, "zeta"}))
+}
@@ -3,63 +3,70 @@ package utils
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/cacoco/codemetagenerator/internal/model"
-	"github.com/ohler55/ojg/gen"
 	"github.com/ohler55/ojg/oj"
 	"github.com/samber/lo"
 	"golang.org/x/exp/maps"
 )
 
 const (
-	SPDXLicensesURL = "https://raw.githubusercontent.com/spdx/license-list-data/master/json/licenses.json"
+	DefaultSPDXLicensesURL = "https://raw.githubusercontent.com/spdx/license-list-data/master/json/licenses.json"
+	ProjectFileName        = "codemeta.json"
 
 	codemetaGeneratorDirectoryName = ".codemetagenerator"
-	inProgressFilePath             = "/" + codemetaGeneratorDirectoryName + "/codemeta.inprogress.json"
-	sPDXLicensesFilePath           = "/" + codemetaGeneratorDirectoryName + "/spdx-licenses.json"
+	inProgressFileName             = "codemeta.inprogress.json"
+	sPDXLicensesFileName           = "spdx-licenses.json"
 )
 
+// the default basedir. An unresolvable user home directory is reported by ResolveDirs.
 var UserHomeDir, _ = getUserHomeDir()
 
+// the URL the SPDX licenses are downloaded from, see the "spdx-url" configuration key
+var SPDXLicensesURL = DefaultSPDXLicensesURL
+
 func getUserHomeDir() (string, error) {
 	return os.UserHomeDir()
 }
 
-func MkHomeDir(basedir string) error {
-	homedir := basedir + "/" + codemetaGeneratorDirectoryName
-	if _, err := os.Stat(homedir); os.IsNotExist(err) {
-		err := os.Mkdir(homedir, 0755)
-		if err != nil {
-			return fmt.Errorf("unable to create codemetagenerator directory: %s", err.Error())
-		}
-	}
-	return nil
-}
-
 func GetHomeDir(basedir string) string {
 	return basedir + "/" + codemetaGeneratorDirectoryName
 }
 
 func GetInProgressFilePath(basedir string) string {
-	return basedir + inProgressFilePath
+	return GetStateDir(basedir) + "/" + inProgressFileName
 }
 
 func GetLicensesFilePath(basedir string) string {
-	return basedir + sPDXLicensesFilePath
+	return GetCacheDir(basedir) + "/" + sPDXLicensesFileName
 }
 
-func ReadJSON(path string) (*string, error) {
-	var p gen.Parser
+// reads the JSON file as pretty-printed JSON with its keys in the order, see Orders
+func ReadJSON(path string, order string) (*string, error) {
 	bytes, err := LoadFile(path)
 	if err != nil {
 		return nil, err
 	}
-
-	node, _ := p.Parse(bytes)
-	json := oj.JSON(node, &oj.Options{Sort: true, Indent: 2, OmitNil: true})
+	json, err := FormatJSONBytes(bytes, order)
+	if err != nil {
+		return nil, err
+	}
 	return &json, nil
 }
 
+// formats the value as pretty-printed JSON with its keys in canonical order
+func FormatJSON(value any) string {
+	bytes, err := oj.Marshal(value, &oj.Options{Sort: true})
+	if err == nil {
+		json, err := FormatJSONBytes(bytes, OrderCanonical)
+		if err == nil {
+			return json
+		}
+	}
+	return oj.JSON(value, &oj.Options{Sort: true, Indent: 2, OmitNil: true})
+}
+
 func WriteJSON(path string, json string) error {
 	return WriteFile(path, []byte(json))
 }
@@ -74,13 +81,28 @@ func Unmarshal(path string) (*map[string]any, error) {
 	return &m, nil
 }
 
+// writes the JSON to the file. The keys of an existing file keep their order, e.g., of
+// the file loaded with "new --input", new keys are in canonical order.
 func MarshalBytes(path string, bytes []byte, args ...any) error {
-	var p gen.Parser
-	node, err := p.Parse(bytes)
+	return MarshalBytesFormatted(path, bytes, DefaultFormat)
+}
+
+// writes the JSON to the file in the format, see MarshalBytes
+func MarshalBytesFormatted(path string, bytes []byte, format Format) error {
+	var json string
+	var err error
+	if existing, readErr := os.ReadFile(path); readErr == nil && len(existing) > 0 {
+		json, err = format.JSONLike(bytes, existing)
+		if err != nil {
+			// an unreadable file has no order to keep
+			json, err = format.JSON(bytes, OrderCanonical)
+		}
+	} else {
+		json, err = format.JSON(bytes, OrderCanonical)
+	}
 	if err != nil {
 		return err
 	}
-	json := oj.JSON(node, &oj.Options{Sort: true, Indent: 2, OmitNil: true})
 	return WriteJSON(path, json)
 }
 
@@ -93,6 +115,16 @@ func Marshal(path string, m map[string]any, args ...any) error {
 	return MarshalBytes(path, bytes, args...)
 }
 
+// writes the JSON to the file with its keys in the order of the keys of the template, see
+// FormatJSONLike
+func MarshalBytesLike(path string, bytes []byte, template []byte) error {
+	json, err := FormatJSONLike(bytes, template)
+	if err != nil {
+		return err
+	}
+	return WriteJSON(path, json)
+}
+
 func LoadFile(filePath string) ([]byte, error) {
 	_, err := os.Stat(filePath)
 	if err != nil {
@@ -155,3 +187,20 @@ func GetSupportedLicenses(basedir string) (*[]string, error) {
 	keys := maps.Keys(licenses)
 	return &keys, nil
 }
+
+// finds a codemeta.json file in the given directory or, failing that, in the root
+// of the enclosing git repository
+func FindProjectFile(dir string) (string, error) {
+	candidate := filepath.Join(dir, ProjectFileName)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate, nil
+	}
+	root, err := FindGitRoot(dir)
+	if err == nil {
+		candidate = filepath.Join(root, ProjectFileName)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("unable to find a %s file in %s or the root of its git repository", ProjectFileName, dir)
+}
@@ -29,7 +29,7 @@ func TestGetInProgressFilePath(t *testing.T) {
 }
 
 func TestReadJSONFailure(t *testing.T) {
-	_, err := ReadJSON("nonexistentfile")
+	_, err := ReadJSON("nonexistentfile", OrderCanonical)
 	if err == nil {
 		t.Errorf("Expected error")
 	}
@@ -48,7 +48,7 @@ func TestWriteReadJSON(t *testing.T) {
 	}
 
 	// read
-	data, err := ReadJSON(path)
+	data, err := ReadJSON(path, OrderCanonical)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
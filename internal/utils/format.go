@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/editorconfig"
+	"github.com/samber/lo"
+)
+
+// Format is the formatting of written JSON files
+type Format struct {
+	// the number of spaces of an indentation level, when not indenting with tabs
+	IndentSize int
+	// whether to indent with tabs instead of spaces
+	Tabs bool
+	// whether to write the JSON on a single line without spaces
+	Minify bool
+	// whether to end the file with a newline
+	FinalNewline bool
+	// whether to end the lines with "\r\n" instead of "\n"
+	CRLF bool
+}
+
+// indented by two spaces, without a final newline
+var DefaultFormat = Format{IndentSize: 2}
+
+func (f Format) indent() string {
+	if f.Tabs {
+		return "\t"
+	}
+	return strings.Repeat(" ", f.IndentSize)
+}
+
+func (f Format) format(value any) (string, error) {
+	var b bytes.Buffer
+	err := f.write(&b, value, 0)
+	if err != nil {
+		return "", err
+	}
+	if f.FinalNewline {
+		b.WriteString("\n")
+	}
+	json := b.String()
+	if f.CRLF {
+		// newlines in strings are escaped, all newlines end lines
+		json = strings.ReplaceAll(json, "\n", "\r\n")
+	}
+	return json, nil
+}
+
+// writes the value as JSON, null values of objects are omitted
+func (f Format) write(b *bytes.Buffer, value any, depth int) error {
+	newline, separator, indent, nested := "\n", ": ", strings.Repeat(f.indent(), depth), strings.Repeat(f.indent(), depth+1)
+	if f.Minify {
+		newline, separator, indent, nested = "", ":", "", ""
+	}
+	switch v := value.(type) {
+	case object:
+		members := lo.Filter(v, func(m member, _ int) bool { return m.value != nil })
+		if len(members) == 0 {
+			b.WriteString("{}")
+			return nil
+		}
+		b.WriteString("{" + newline)
+		for i, m := range members {
+			b.WriteString(nested)
+			err := writeScalar(b, m.key)
+			if err != nil {
+				return err
+			}
+			b.WriteString(separator)
+			err = f.write(b, m.value, depth+1)
+			if err != nil {
+				return err
+			}
+			if i < len(members)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString(newline)
+		}
+		b.WriteString(indent + "}")
+	case []any:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return nil
+		}
+		b.WriteString("[" + newline)
+		for i, item := range v {
+			b.WriteString(nested)
+			err := f.write(b, item, depth+1)
+			if err != nil {
+				return err
+			}
+			if i < len(v)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString(newline)
+		}
+		b.WriteString(indent + "]")
+	default:
+		return writeScalar(b, v)
+	}
+	return nil
+}
+
+// writes a string, number, boolean or null, HTML characters, e.g., the "&" of URLs, are
+// not escaped
+func writeScalar(b *bytes.Buffer, value any) error {
+	encoder := json.NewEncoder(b)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(value)
+	if err != nil {
+		return err
+	}
+	// the encoder terminates the value with a newline
+	b.Truncate(b.Len() - 1)
+	return nil
+}
+
+// returns the format with the properties of an .editorconfig file applied, see:
+// https://editorconfig.org. Unknown values are ignored.
+func (f Format) WithEditorConfig(properties map[string]string) Format {
+	switch properties[editorconfig.IndentStyle] {
+	case "tab":
+		f.Tabs = true
+	case "space":
+		f.Tabs = false
+	}
+	size := properties[editorconfig.IndentSize]
+	if size == "tab" {
+		size = properties[editorconfig.TabWidth]
+	}
+	if n, err := strconv.Atoi(size); err == nil && n >= 0 {
+		f.IndentSize = n
+	}
+	switch properties[editorconfig.InsertFinalNewline] {
+	case "true":
+		f.FinalNewline = true
+	case "false":
+		f.FinalNewline = false
+	}
+	switch properties[editorconfig.EndOfLine] {
+	case "crlf":
+		f.CRLF = true
+	case "lf":
+		f.CRLF = false
+	}
+	return f
+}
+
+// returns the format of the JSON file at the path, e.g., a project codemeta.json file. In
+// a git repository the .editorconfig files of the repository apply, the default format
+// otherwise.
+func FormatOf(path string) Format {
+	root, err := FindGitRoot(filepath.Dir(path))
+	if err != nil {
+		return DefaultFormat
+	}
+	properties, err := editorconfig.Lookup(path, root)
+	if err != nil {
+		return DefaultFormat
+	}
+	return DefaultFormat.WithEditorConfig(properties)
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestFormat(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	data := []byte(`{"name": "a", "keywords": ["b", "c"], "author": {}}`)
+	json, err := Format{Minify: true}.JSON(data, OrderPreserve)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(json).Should(gomega.Equal(`{"name":"a","keywords":["b","c"],"author":{}}`))
+
+	json, err = Format{Tabs: true, FinalNewline: true}.JSON(data, OrderPreserve)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(json).Should(gomega.Equal("{\n\t\"name\": \"a\",\n\t\"keywords\": [\n\t\t\"b\",\n\t\t\"c\"\n\t],\n\t\"author\": {}\n}\n"))
+
+	json, err = Format{IndentSize: 4, CRLF: true, FinalNewline: true}.JSON([]byte(`{"name": "a\nb"}`), OrderPreserve)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(json).Should(gomega.Equal("{\r\n    \"name\": \"a\\nb\"\r\n}\r\n"))
+}
+
+func TestWithEditorConfig(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	format := DefaultFormat.WithEditorConfig(map[string]string{"indent_style": "tab", "insert_final_newline": "true", "end_of_line": "crlf"})
+	g.Ω(format).Should(gomega.Equal(Format{IndentSize: 2, Tabs: true, FinalNewline: true, CRLF: true}))
+	format = DefaultFormat.WithEditorConfig(map[string]string{"indent_style": "space", "indent_size": "tab", "tab_width": "8", "end_of_line": "cr"})
+	g.Ω(format).Should(gomega.Equal(Format{IndentSize: 8}))
+	format = DefaultFormat.WithEditorConfig(map[string]string{"indent_size": "unset"})
+	g.Ω(format).Should(gomega.Equal(DefaultFormat))
+}
+
+func TestFormatOf(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	root := t.TempDir()
+	err := os.WriteFile(filepath.Join(root, ".editorconfig"), []byte("[*.json]\nindent_size = 4\n"), 0644)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// outside of a git repository the .editorconfig file is ignored
+	g.Ω(FormatOf(filepath.Join(root, "codemeta.json"))).Should(gomega.Equal(DefaultFormat))
+
+	err = os.Mkdir(filepath.Join(root, ".git"), 0755)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g.Ω(FormatOf(filepath.Join(root, "codemeta.json"))).Should(gomega.Equal(Format{IndentSize: 4}))
+}
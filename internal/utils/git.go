@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// walks up from the given directory to find the root of the enclosing git repository
+func FindGitRoot(dir string) (string, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		// .git is a directory in a regular repository and a file in a worktree or submodule
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current, nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", fmt.Errorf("not inside a git repository: %s", dir)
+		}
+		current = parent
+	}
+}
+
+// normalizes a git remote URL to an https URL without a ".git" suffix, e.g.,
+// git@github.com:org/repo.git => https://github.com/org/repo
+func NormalizeRepositoryURL(remote string) string {
+	url := strings.TrimSpace(remote)
+	url = strings.TrimPrefix(url, "git+")
+	switch {
+	case strings.HasPrefix(url, "ssh://"):
+		url = strings.TrimPrefix(url, "ssh://")
+		url = url[strings.Index(url, "@")+1:]
+		// drop any port
+		if host, path, ok := strings.Cut(url, "/"); ok {
+			host, _, _ = strings.Cut(host, ":")
+			url = host + "/" + path
+		}
+		url = "https://" + url
+	case strings.HasPrefix(url, "git://"):
+		url = "https://" + strings.TrimPrefix(url, "git://")
+	case strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "https://"):
+		// drop any credentials
+		scheme, rest, _ := strings.Cut(url, "://")
+		if at := strings.Index(rest, "@"); at >= 0 && at < strings.Index(rest+"/", "/") {
+			rest = rest[at+1:]
+		}
+		url = scheme + "://" + rest
+	case strings.Contains(url, "@") && strings.Contains(url, ":"):
+		// scp-like syntax: user@host:path
+		url = url[strings.Index(url, "@")+1:]
+		url = "https://" + strings.Replace(url, ":", "/", 1)
+	}
+	url = strings.TrimSuffix(url, "/")
+	return strings.TrimSuffix(url, ".git")
+}
+
+// returns the git directory of the repository rooted at root. In a worktree or submodule
+// ".git" is a file pointing at the git directory.
+func gitDir(root string) (string, error) {
+	path := filepath.Join(root, ".git")
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return path, nil
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	dir, ok := strings.CutPrefix(strings.TrimSpace(string(bytes)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("invalid .git file: %s", path)
+	}
+	dir = strings.TrimSpace(dir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	// a worktree shares the config of the main repository
+	if common, err := os.ReadFile(filepath.Join(dir, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(dir, commonDir)
+		}
+		return commonDir, nil
+	}
+	return dir, nil
+}
+
+// returns the URL of the "origin" remote, or of the first remote when there is no
+// "origin", from the config of the git repository rooted at root
+func GitRemoteURL(root string) (string, error) {
+	dir, err := gitDir(root)
+	if err != nil {
+		return "", err
+	}
+	bytes, err := os.ReadFile(filepath.Join(dir, "config"))
+	if err != nil {
+		return "", err
+	}
+	remote := ""
+	first := ""
+	for _, line := range strings.Split(string(bytes), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			remote = ""
+			section := strings.Trim(line, "[]")
+			if name, ok := strings.CutPrefix(section, "remote "); ok {
+				remote = strings.Trim(strings.TrimSpace(name), `"`)
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if remote == "" || !ok || strings.TrimSpace(key) != "url" {
+			continue
+		}
+		url := strings.TrimSpace(value)
+		if remote == "origin" {
+			return url, nil
+		}
+		if first == "" {
+			first = url
+		}
+	}
+	if first == "" {
+		return "", fmt.Errorf("no git remote found in %s", root)
+	}
+	return first, nil
+}
+
+// returns the user.name and user.email from the git config, empty when not set
+func GitUser() (string, string) {
+	get := func(key string) string {
+		out, err := exec.Command("git", "config", "--get", key).Output()
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(out))
+	}
+	return get("user.name"), get("user.email")
+}
@@ -7,9 +7,14 @@ import (
 	"time"
 )
 
+const DefaultHttpTimeout = time.Second * 2
+
+// the timeout of HTTP requests, see the "http-timeout" configuration key
+var HttpTimeout = DefaultHttpTimeout
+
 func MkHttpClient() *http.Client {
 	client := http.Client{
-		Timeout: time.Second * 2, // Timeout after 2 seconds
+		Timeout: HttpTimeout,
 	}
 
 	return &client
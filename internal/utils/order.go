@@ -0,0 +1,211 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+	"github.com/samber/lo"
+)
+
+// the key orders of written JSON files
+const (
+	// the order of model.CanonicalOrder, other keys follow in alphabetical order
+	OrderCanonical = "canonical"
+	// alphabetical order
+	OrderAlpha = "alpha"
+	// the order of the keys in the file
+	OrderPreserve = "preserve"
+)
+
+var Orders = []string{OrderCanonical, OrderAlpha, OrderPreserve}
+
+// the rank of the keys of model.CanonicalOrder
+var canonicalRank = lo.SliceToMap(model.CanonicalOrder, func(key string) (string, int) {
+	return key, lo.IndexOf(model.CanonicalOrder, key)
+})
+
+// a key and its value of a JSON object
+type member struct {
+	key   string
+	value any
+}
+
+// a JSON object which keeps the order of its keys
+type object []member
+
+func (o object) get(key string) (any, bool) {
+	for _, m := range o {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return nil, false
+}
+
+func ValidOrder(order string) error {
+	if !lo.Contains(Orders, order) {
+		return fmt.Errorf("invalid order: %s, must be one of: %s", order, strings.Join(Orders, ", "))
+	}
+	return nil
+}
+
+// parses JSON keeping the order of the keys of its objects, numbers are kept as written
+func parseOrdered(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the top-level value")
+	}
+	return value, nil
+}
+
+func decodeOrdered(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		o := object{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			o = append(o, member{key: key.(string), value: value})
+		}
+		_, err = decoder.Token()
+		return o, err
+	case json.Delim('['):
+		list := []any{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	}
+	return token, nil
+}
+
+// returns whether key a is before key b in canonical order
+func canonicalLess(a string, b string) bool {
+	rankA, knownA := canonicalRank[a]
+	rankB, knownB := canonicalRank[b]
+	switch {
+	case knownA && knownB:
+		return rankA < rankB
+	case knownA || knownB:
+		return knownA
+	}
+	return a < b
+}
+
+// orders the keys of the objects of the value. With a template the keys of an object are
+// in the order of the keys of the object at the same place in the template, keys which are
+// not in the template follow in canonical order.
+func orderKeys(value any, order string, template any) any {
+	switch v := value.(type) {
+	case object:
+		t, _ := template.(object)
+		ordered := make(object, len(v))
+		copy(ordered, v)
+		switch {
+		case t != nil:
+			positions := map[string]int{}
+			for i, m := range t {
+				positions[m.key] = i
+			}
+			sort.SliceStable(ordered, func(i, j int) bool {
+				a, knownA := positions[ordered[i].key]
+				b, knownB := positions[ordered[j].key]
+				switch {
+				case knownA && knownB:
+					return a < b
+				case knownA || knownB:
+					return knownA
+				}
+				return canonicalLess(ordered[i].key, ordered[j].key)
+			})
+		case order == OrderCanonical:
+			sort.SliceStable(ordered, func(i, j int) bool { return canonicalLess(ordered[i].key, ordered[j].key) })
+		case order == OrderAlpha:
+			sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].key < ordered[j].key })
+		}
+		for i, m := range ordered {
+			var nested any
+			if t != nil {
+				nested, _ = t.get(m.key)
+			}
+			ordered[i].value = orderKeys(m.value, order, nested)
+		}
+		return ordered
+	case []any:
+		t, _ := template.([]any)
+		list := make([]any, len(v))
+		for i, item := range v {
+			var nested any
+			if i < len(t) {
+				nested = t[i]
+			}
+			list[i] = orderKeys(item, order, nested)
+		}
+		return list
+	}
+	return value
+}
+
+// formats the JSON as pretty-printed JSON with its keys in the order, see Orders
+func FormatJSONBytes(data []byte, order string) (string, error) {
+	return DefaultFormat.JSON(data, order)
+}
+
+// formats the JSON in the format with its keys in the order, see Orders
+func (f Format) JSON(data []byte, order string) (string, error) {
+	err := ValidOrder(order)
+	if err != nil {
+		return "", err
+	}
+	value, err := parseOrdered(data)
+	if err != nil {
+		return "", err
+	}
+	return f.format(orderKeys(value, order, nil))
+}
+
+// formats the JSON as pretty-printed JSON with its keys in the order of the keys of the
+// template, e.g., of the file it replaces. Keys which are not in the template follow in
+// canonical order.
+func FormatJSONLike(data []byte, template []byte) (string, error) {
+	return DefaultFormat.JSONLike(data, template)
+}
+
+// formats the JSON in the format with its keys in the order of the keys of the template,
+// see FormatJSONLike
+func (f Format) JSONLike(data []byte, template []byte) (string, error) {
+	value, err := parseOrdered(data)
+	if err != nil {
+		return "", err
+	}
+	t, err := parseOrdered(template)
+	if err != nil {
+		return "", err
+	}
+	return f.format(orderKeys(value, OrderCanonical, t))
+}
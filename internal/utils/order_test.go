@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestFormatJSONBytes(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	data := []byte(`{"version": 2.50, "zeta": null, "name": "a", "@type": "SoftwareSourceCode", "x": {"b": [], "a": {}}, "@context": "https://w3id.org/codemeta/3.0"}`)
+	json, err := FormatJSONBytes(data, OrderCanonical)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(json).Should(gomega.Equal(`{
+  "@context": "https://w3id.org/codemeta/3.0",
+  "@type": "SoftwareSourceCode",
+  "name": "a",
+  "version": 2.50,
+  "x": {
+    "a": {},
+    "b": []
+  }
+}`))
+
+	json, err = FormatJSONBytes(data, OrderAlpha)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(json).Should(gomega.HavePrefix("{\n  \"@context\": \"https://w3id.org/codemeta/3.0\",\n  \"@type\": \"SoftwareSourceCode\",\n  \"name\""))
+
+	json, err = FormatJSONBytes(data, OrderPreserve)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(json).Should(gomega.HavePrefix("{\n  \"version\": 2.50,\n  \"name\": \"a\""))
+
+	_, err = FormatJSONBytes(data, "random")
+	g.Expect(err).ToNot(gomega.BeNil())
+	_, err = FormatJSONBytes([]byte(`{"a": 1} {}`), OrderCanonical)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestFormatJSONLike(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	template := []byte(`{"name": "a", "author": [{"email": "e", "@type": "Person"}], "@type": "SoftwareSourceCode"}`)
+	data := []byte(`{"@type": "SoftwareSourceCode", "version": "1", "author": [{"@type": "Person", "email": "e"}, {"givenName": "J", "@type": "Person"}], "name": "b", "@context": "c"}`)
+	json, err := FormatJSONLike(data, template)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(json).Should(gomega.Equal(`{
+  "name": "b",
+  "author": [
+    {
+      "email": "e",
+      "@type": "Person"
+    },
+    {
+      "@type": "Person",
+      "givenName": "J"
+    }
+  ],
+  "@type": "SoftwareSourceCode",
+  "@context": "c",
+  "version": "1"
+}`))
+}
+
+func TestMarshalKeepsOrder(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	path := t.TempDir() + "/test.json"
+	err := WriteJSON(path, `{"name": "a", "@type": "SoftwareSourceCode"}`)
+	g.Expect(err).To(gomega.BeNil())
+	err = Marshal(path, map[string]any{"@type": "SoftwareSourceCode", "name": "b", "identifier": "c", "@context": "d"})
+	g.Expect(err).To(gomega.BeNil())
+	bytes, err := LoadFile(path)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(string(bytes)).Should(gomega.Equal("{\n  \"name\": \"b\",\n  \"@type\": \"SoftwareSourceCode\",\n  \"@context\": \"d\",\n  \"identifier\": \"c\"\n}"))
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"os"
+)
+
+const profileFileName = "profile.json"
+
+func GetProfileFilePath(basedir string) string {
+	return GetConfigDir(basedir) + "/" + profileFileName
+}
+
+// returns the saved "me" profile, or nil when no profile has been saved
+func LoadProfile(basedir string) (*map[string]any, error) {
+	path := GetProfileFilePath(basedir)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return Unmarshal(path)
+}
+
+func SaveProfile(basedir string, person map[string]any) error {
+	err := MkHomeDir(basedir)
+	if err != nil {
+		return err
+	}
+	return Marshal(GetProfileFilePath(basedir), person)
+}
@@ -14,10 +14,16 @@ func Nop(s string) error {
 }
 
 func MkPrompt(stdin *io.ReadCloser, stdout *io.WriteCloser, text string, validate func(string) error) (*string, error) {
+	return MkPromptWithDefault(stdin, stdout, text, "", validate)
+}
+
+// prompts for a value, an empty answer accepts the default value
+func MkPromptWithDefault(stdin *io.ReadCloser, stdout *io.WriteCloser, text string, defaultValue string, validate func(string) error) (*string, error) {
 	prompt := promptui.Prompt{
-		Label:  text,
-		Stdin:  *stdin,
-		Stdout: *stdout,
+		Label:   text,
+		Default: defaultValue,
+		Stdin:   *stdin,
+		Stdout:  *stdout,
 	}
 
 	result, err := prompt.Run()
@@ -33,14 +39,201 @@ func MkPrompt(stdin *io.ReadCloser, stdout *io.WriteCloser, text string, validat
 	return &result, nil
 }
 
-func NewPersonOrOrganizationPrompt(reader *Reader, writer *Writer, label string) (*map[string]any, error) {
+// asks a yes or no question, an empty answer is yes
+func MkConfirm(stdin *io.ReadCloser, stdout *io.WriteCloser, text string) (bool, error) {
+	prompt := promptui.Prompt{
+		Label:     text,
+		IsConfirm: true,
+		Default:   "y",
+		Stdin:     *stdin,
+		Stdout:    *stdout,
+	}
+
+	_, err := prompt.Run()
+	if err == promptui.ErrAbort {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Input holds values supplied up front, e.g., from command-line flags, keyed by flag
+// name. Prompts are only shown for missing values and when NoInput is set, a missing
+// value is an error instead of a prompt.
+type Input struct {
+	Values  map[string]string
+	NoInput bool
+	prefix  string
+}
+
+// returns a view of the input where every flag name is prefixed with the given prefix
+func (in *Input) WithPrefix(prefix string) *Input {
+	if in == nil {
+		return &Input{prefix: prefix}
+	}
+	return &Input{Values: in.Values, NoInput: in.NoInput, prefix: in.prefix + prefix}
+}
+
+func (in *Input) Flag(name string) string {
+	if in == nil {
+		return name
+	}
+	return in.prefix + name
+}
+
+func (in *Input) Get(name string) (string, bool) {
+	if in == nil || in.Values == nil {
+		return "", false
+	}
+	value, ok := in.Values[in.Flag(name)]
+	return value, ok
+}
+
+func (in *Input) Has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := in.Get(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *Input) IsNoInput() bool {
+	return in != nil && in.NoInput
+}
+
+func (in *Input) Missing(name string) error {
+	return fmt.Errorf("missing value for --%s, prompting is disabled by --no-input", in.Flag(name))
+}
+
+// returns the value given for the named flag, prompting for it when it is missing. When
+// prompting is disabled every missing value is an error, optional values are left empty
+// by passing an empty flag value.
+func (in *Input) Prompt(stdin *io.ReadCloser, stdout *io.WriteCloser, name string, text string, validate func(string) error) (*string, error) {
+	if value, ok := in.Get(name); ok {
+		err := validate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for --%s: %s", in.Flag(name), err.Error())
+		}
+		return &value, nil
+	}
+	if in.IsNoInput() {
+		return nil, in.Missing(name)
+	}
+	return MkPrompt(stdin, stdout, text, validate)
+}
+
+// like Prompt, but the prompt offers the default value, which is also used when
+// prompting is disabled
+func (in *Input) PromptWithDefault(stdin *io.ReadCloser, stdout *io.WriteCloser, name string, text string, defaultValue string, validate func(string) error) (*string, error) {
+	if _, ok := in.Get(name); ok || defaultValue == "" {
+		return in.Prompt(stdin, stdout, name, text, validate)
+	}
+	if in.IsNoInput() {
+		return &defaultValue, nil
+	}
+	return MkPromptWithDefault(stdin, stdout, text, defaultValue, validate)
+}
+
+// returns the value given for the named flag. Otherwise the user is asked to confirm the
+// inferred value and prompted for another value when it is rejected. When prompting is
+// disabled the inferred value is used.
+func (in *Input) PromptInferred(stdin *io.ReadCloser, stdout *io.WriteCloser, name string, text string, inferred string, validate func(string) error) (*string, error) {
+	if _, ok := in.Get(name); ok || inferred == "" {
+		return in.Prompt(stdin, stdout, name, text, validate)
+	}
+	if in.IsNoInput() {
+		return &inferred, nil
+	}
+	ok, err := MkConfirm(stdin, stdout, fmt.Sprintf("Use the inferred %s %s", strings.ReplaceAll(name, "-", " "), inferred))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return &inferred, nil
+	}
+	return MkPrompt(stdin, stdout, text, validate)
+}
+
+// reports whether all characters of the input appear in order in the text, ignoring case
+// and spaces, e.g., "jdoe" matches "Jane Doe <jane@example.org>"
+func FuzzyMatch(text string, input string) bool {
+	text = strings.ToLower(text)
+	input = strings.ToLower(strings.ReplaceAll(input, " ", ""))
+	for _, r := range input {
+		index := strings.IndexRune(text, r)
+		if index < 0 {
+			return false
+		}
+		text = text[index+len(string(r)):]
+	}
+	return true
+}
+
+// prompts for a person or an organization. Any saved people, e.g., the "me" profile, are
+// offered in addition to entering a new person or organization.
+func NewPersonOrOrganizationPrompt(reader *Reader, writer *Writer, label string, input *Input, saved []map[string]any) (*map[string]any, error) {
+	return NewPartyPrompt(reader, writer, label, input, saved, true, true)
+}
+
+// prompts for a person and/or an organization, as allowed. Only the saved people of an
+// allowed type are offered.
+func NewPartyPrompt(reader *Reader, writer *Writer, label string, input *Input, saved []map[string]any, allowPerson bool, allowOrganization bool) (*map[string]any, error) {
 	stdin := (*reader).Stdin()
 	stdout := (*writer).Stdout()
 
-	options := []model.MenuOption{
-		{Name: "Person", Type: "person"},
-		{Name: "Organization", Type: "organization"},
+	isPerson := input.Has("given-name", "family-name", "email", "orcid")
+	isOrganization := input.Has("org-name", "url", "org-id")
+	if isPerson && isOrganization {
+		return nil, fmt.Errorf("%s flags for both a person and an organization were given", strings.ToLower(label))
+	}
+	if isPerson && !allowPerson {
+		return nil, fmt.Errorf("%s must be an organization", strings.ToLower(label))
+	}
+	if isOrganization && !allowOrganization {
+		return nil, fmt.Errorf("%s must be a person", strings.ToLower(label))
+	}
+	if isPerson {
+		return newPerson(&stdin, &stdout, input, nil)
+	}
+	if isOrganization {
+		return newOrganization(&stdin, &stdout, input)
 	}
+	if input.IsNoInput() {
+		switch {
+		case allowPerson && allowOrganization:
+			return nil, fmt.Errorf("missing %s, pass either --%s or --%s, prompting is disabled by --no-input", strings.ToLower(label), input.Flag("given-name"), input.Flag("org-name"))
+		case allowPerson:
+			return nil, fmt.Errorf("missing %s, pass --%s, prompting is disabled by --no-input", strings.ToLower(label), input.Flag("given-name"))
+		default:
+			return nil, fmt.Errorf("missing %s, pass --%s, prompting is disabled by --no-input", strings.ToLower(label), input.Flag("org-name"))
+		}
+	}
+
+	options := []model.MenuOption{}
+	if allowPerson {
+		options = append(options, model.MenuOption{Name: "Person", Type: "person"})
+	}
+	if allowOrganization {
+		options = append(options, model.MenuOption{Name: "Organization", Type: "organization"})
+	}
+	offered := []map[string]any{}
+	for _, party := range saved {
+		if (party[model.Type] == model.PersonType && allowPerson) || (party[model.Type] == model.OrganizationType && allowOrganization) {
+			offered = append(offered, party)
+			options = append(options, model.MenuOption{Name: model.PartyLabel(party), Type: "saved"})
+		}
+	}
+	// nothing to choose from
+	if len(options) == 1 {
+		if allowPerson {
+			return newPerson(&stdin, &stdout, input, nil)
+		}
+		return newOrganization(&stdin, &stdout, input)
+	}
+
 	templates := &promptui.SelectTemplates{
 		Label:    "{{ . }}",
 		Active:   "➞ {{ .Name | cyan }}",
@@ -54,11 +247,19 @@ func NewPersonOrOrganizationPrompt(reader *Reader, writer *Writer, label string)
 		Label:     "Please enter a " + label + " type:",
 		Items:     options,
 		Templates: templates,
-		Size:      2,
+		Size:      min(len(options), 10),
 		Searcher:  nil,
 		Stdin:     stdin,
 		Stdout:    stdout,
 	}
+	if len(offered) > 0 {
+		// type to search the saved people
+		prompt.Label = "Please enter a " + label + " type or search the saved people:"
+		prompt.Searcher = func(input string, index int) bool {
+			return FuzzyMatch(options[index].Name, input)
+		}
+		prompt.StartInSearchMode = true
+	}
 
 	i, _, err := prompt.Run()
 	if err != nil {
@@ -67,38 +268,60 @@ func NewPersonOrOrganizationPrompt(reader *Reader, writer *Writer, label string)
 	keyType := options[i].Type
 	switch keyType {
 	case "person":
-		givenName, err := MkPrompt(&stdin, &stdout, "Enter the given (first) name of the person", Nop)
-		if err != nil {
-			return nil, err
-		}
-		familyName, err := MkPrompt(&stdin, &stdout, "Enter the family (last) name of the person", Nop)
-		if err != nil {
-			return nil, err
-		}
-		email, err := MkPrompt(&stdin, &stdout, "Enter the email address of the person", ValidEmailAddress)
-		if err != nil {
-			return nil, err
-		}
-		id, err := MkPrompt(&stdin, &stdout, "Enter the identifier of the person (see: https://orcid.org)", Nop)
-		if err != nil {
-			return nil, err
-		}
-		return model.NewPerson(givenName, familyName, email, id), nil
+		return newPerson(&stdin, &stdout, input, nil)
 	case "organization":
-		name, err := MkPrompt(&stdin, &stdout, "Enter the name of the organization", Nop)
-		if err != nil {
-			return nil, err
-		}
-		url, err := MkPrompt(&stdin, &stdout, "Enter the URL of the organization", ValidUrl)
-		if err != nil {
-			return nil, err
-		}
-		id, err := MkPrompt(&stdin, &stdout, "Enter the identifier of the organization (see: https://orcid.org)", Nop)
-		if err != nil {
-			return nil, err
-		}
-		return model.NewOrganization(name, url, id), nil
+		return newOrganization(&stdin, &stdout, input)
+	case "saved":
+		party := offered[i-(len(options)-len(offered))]
+		return &party, nil
 	default:
 		return nil, fmt.Errorf("Invalid selection: " + keyType)
 	}
 }
+
+// prompts for a person, offering the values of the defaults person, if any
+func NewPersonPrompt(reader *Reader, writer *Writer, input *Input, defaults map[string]any) (*map[string]any, error) {
+	stdin := (*reader).Stdin()
+	stdout := (*writer).Stdout()
+	return newPerson(&stdin, &stdout, input, defaults)
+}
+
+func newPerson(stdin *io.ReadCloser, stdout *io.WriteCloser, input *Input, defaults map[string]any) (*map[string]any, error) {
+	defaultOf := func(key string) string {
+		value, _ := defaults[key].(string)
+		return value
+	}
+	givenName, err := input.PromptWithDefault(stdin, stdout, "given-name", "Enter the given (first) name of the person", defaultOf(model.GivenName), Nop)
+	if err != nil {
+		return nil, err
+	}
+	familyName, err := input.PromptWithDefault(stdin, stdout, "family-name", "Enter the family (last) name of the person", defaultOf(model.FamilyName), Nop)
+	if err != nil {
+		return nil, err
+	}
+	email, err := input.PromptWithDefault(stdin, stdout, "email", "Enter the email address of the person", defaultOf(model.Email), ValidEmailAddress)
+	if err != nil {
+		return nil, err
+	}
+	id, err := input.PromptWithDefault(stdin, stdout, "orcid", "Enter the identifier of the person (see: https://orcid.org)", defaultOf(model.Id), Nop)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewPerson(givenName, familyName, email, id), nil
+}
+
+func newOrganization(stdin *io.ReadCloser, stdout *io.WriteCloser, input *Input) (*map[string]any, error) {
+	name, err := input.Prompt(stdin, stdout, "org-name", "Enter the name of the organization", Nop)
+	if err != nil {
+		return nil, err
+	}
+	url, err := input.Prompt(stdin, stdout, "url", "Enter the URL of the organization", ValidUrl)
+	if err != nil {
+		return nil, err
+	}
+	id, err := input.Prompt(stdin, stdout, "org-id", "Enter the identifier of the organization, e.g., a ROR ID (see: https://ror.org)", Nop)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewOrganization(name, url, id), nil
+}
@@ -116,3 +116,141 @@ func TestMkPromptValidEmailAddress(t *testing.T) {
 	g.Expect(selection).ToNot(gomega.BeNil())
 	g.Ω(*selection).Should(gomega.Equal("person@email.org"))
 }
+
+func TestNewPersonOrOrganizationPromptWithValues(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// only the missing ORCID is prompted for
+	var stack Stack[string]
+	stack.Push("https://orcid.org/0000-0000-0000-0000\n")
+	var reader Reader = &TestReader{In: TestStdin{Data: stack}}
+	var writer Writer = TestWriter{}
+
+	input := &Input{Values: map[string]string{
+		"given-name":  "Alice",
+		"family-name": "Smith",
+		"email":       "alice@smith.org",
+	}}
+	person, err := NewPersonOrOrganizationPrompt(&reader, &writer, "Author", input, nil)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*person).Should(gomega.Equal(map[string]any{
+		"@type":      "Person",
+		"givenName":  "Alice",
+		"familyName": "Smith",
+		"email":      "alice@smith.org",
+		"@id":        "https://orcid.org/0000-0000-0000-0000",
+	}))
+}
+
+func TestNewPersonOrOrganizationPromptNoInput(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var reader Reader = &TestReader{In: TestStdin{Data: *NilStack}}
+	var writer Writer = TestWriter{}
+
+	// missing values are errors
+	input := &Input{Values: map[string]string{"org-name": "Org"}, NoInput: true}
+	_, err := NewPersonOrOrganizationPrompt(&reader, &writer, "Author", input, nil)
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Ω(err.Error()).Should(gomega.ContainSubstring("--url"))
+
+	// no values at all
+	_, err = NewPersonOrOrganizationPrompt(&reader, &writer, "Author", &Input{NoInput: true}, nil)
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	// person and organization values together
+	input = &Input{Values: map[string]string{"org-name": "Org", "given-name": "Alice"}}
+	_, err = NewPersonOrOrganizationPrompt(&reader, &writer, "Author", input, nil)
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	// invalid values are errors
+	input = &Input{Values: map[string]string{"given-name": "Alice", "family-name": "Smith", "email": "invalid", "orcid": ""}}
+	_, err = NewPersonOrOrganizationPrompt(&reader, &writer, "Author", input, nil)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestPromptNoInput(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	reader := TestReader{In: TestStdin{Data: *NilStack}}
+	stdin := reader.Stdin()
+	writer := TestWriter{}
+	stdout := writer.Stdout()
+
+	// a missing value is an error, even an optional one
+	_, err := (&Input{NoInput: true}).Prompt(&stdin, &stdout, "version", "Enter the version", Nop)
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Ω(err.Error()).Should(gomega.ContainSubstring("--version"))
+
+	// an empty flag value leaves an optional value empty
+	value, err := (&Input{Values: map[string]string{"version": ""}, NoInput: true}).Prompt(&stdin, &stdout, "version", "Enter the version", Nop)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*value).Should(gomega.BeEmpty())
+}
+
+func TestInputWithPrefix(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	input := &Input{Values: map[string]string{"maintainer-email": "alice@smith.org"}}
+	value, ok := input.WithPrefix("maintainer-").Get("email")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Ω(value).Should(gomega.Equal("alice@smith.org"))
+	g.Expect(input.Has("email")).To(gomega.BeFalse())
+}
+
+func TestPromptInferred(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	writer := TestWriter{}
+	stdout := writer.Stdout()
+
+	// the inferred value is confirmed
+	var stack Stack[string]
+	stack.Push("\n")
+	reader := TestReader{In: TestStdin{Data: stack}}
+	stdin := reader.Stdin()
+	value, err := (&Input{}).PromptInferred(&stdin, &stdout, "url", "Enter a URL", "https://inferred.org", ValidUrl)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*value).Should(gomega.Equal("https://inferred.org"))
+
+	// the inferred value is rejected and another one is prompted for
+	stack = Stack[string]{}
+	stack.Push("https://other.org\n")
+	stack.Push("n\n")
+	reader = TestReader{In: TestStdin{Data: stack}}
+	stdin = reader.Stdin()
+	value, err = (&Input{}).PromptInferred(&stdin, &stdout, "url", "Enter a URL", "https://inferred.org", ValidUrl)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*value).Should(gomega.Equal("https://other.org"))
+
+	// a flag takes precedence, without prompting the inferred value is used
+	reader = TestReader{In: TestStdin{Data: *NilStack}}
+	stdin = reader.Stdin()
+	value, err = (&Input{Values: map[string]string{"url": "https://flag.org"}}).PromptInferred(&stdin, &stdout, "url", "Enter a URL", "https://inferred.org", ValidUrl)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*value).Should(gomega.Equal("https://flag.org"))
+	value, err = (&Input{NoInput: true}).PromptInferred(&stdin, &stdout, "url", "Enter a URL", "https://inferred.org", ValidUrl)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	g.Ω(*value).Should(gomega.Equal("https://inferred.org"))
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Ω(FuzzyMatch("Jane Doe <jane@example.org>", "jdoe")).Should(gomega.BeTrue())
+	g.Ω(FuzzyMatch("Jane Doe <jane@example.org>", "Jane D")).Should(gomega.BeTrue())
+	g.Ω(FuzzyMatch("Jane Doe <jane@example.org>", "")).Should(gomega.BeTrue())
+	g.Ω(FuzzyMatch("Jane Doe <jane@example.org>", "jane@example.com")).Should(gomega.BeFalse())
+}
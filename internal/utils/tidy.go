@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// removes the empty strings, empty objects and empty arrays of the JSON, e.g., of prompts
+// answered by pressing Enter, keeping the order of its keys. Objects and arrays which are
+// only empty after their empty values are removed are removed as well, the top-level value
+// is kept. Returns the compact JSON and the paths of the removed values in path syntax.
+func Tidy(data []byte) ([]byte, []string, error) {
+	value, err := parseOrdered(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	removed := []string{}
+	tidied, keep := prune(value, "", &removed)
+	if !keep {
+		// an empty top-level value stays an empty object or array
+		switch value.(type) {
+		case object:
+			tidied = object{}
+		case []any:
+			tidied = []any{}
+		}
+	}
+	json, err := Format{Minify: true}.format(tidied)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []byte(json), removed, nil
+}
+
+// returns the value without its empty values and whether the value itself is kept
+func prune(value any, path string, removed *[]string) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case object:
+		pruned := object{}
+		for _, m := range v {
+			if m.value == nil {
+				// null members are omitted when written
+				continue
+			}
+			memberPath := joinPath(path, m.key)
+			nested, keep := prune(m.value, memberPath, removed)
+			if !keep {
+				*removed = append(*removed, memberPath)
+				continue
+			}
+			pruned = append(pruned, member{key: m.key, value: nested})
+		}
+		return pruned, len(pruned) > 0
+	case []any:
+		pruned := []any{}
+		for i, item := range v {
+			itemPath := joinPath(path, strconv.Itoa(i))
+			nested, keep := prune(item, itemPath, removed)
+			if !keep {
+				*removed = append(*removed, itemPath)
+				continue
+			}
+			pruned = append(pruned, nested)
+		}
+		return pruned, len(pruned) > 0
+	}
+	return value, true
+}
+
+// appends the key to the path, escaping the characters which are special in the path syntax
+func joinPath(path string, key string) string {
+	key = strings.NewReplacer(".", "\\.", "*", "\\*", "?", "\\?").Replace(key)
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestTidy(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	data := []byte(`{"name": "a", "license": "", "version": 1.0, "keywords": ["b", "", []], "author": [{"@type": "Person", "@id": ""}, {"@id": "", "email": ""}], "a.b": {}, "funder": null, "isAccessibleForFree": false}`)
+	tidied, removed, err := Tidy(data)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(string(tidied)).Should(gomega.Equal(`{"name":"a","version":1.0,"keywords":["b"],"author":[{"@type":"Person"}],"isAccessibleForFree":false}`))
+	g.Ω(removed).Should(gomega.Equal([]string{"license", "keywords.1", "keywords.2", "author.0.@id", "author.1.@id", "author.1.email", "author.1", `a\.b`}))
+
+	tidied, removed, err = Tidy([]byte(`{"name": ""}`))
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(string(tidied)).Should(gomega.Equal(`{}`))
+	g.Ω(removed).Should(gomega.Equal([]string{"name"}))
+
+	_, _, err = Tidy([]byte(`{"name": `))
+	g.Expect(err).NotTo(gomega.BeNil())
+}
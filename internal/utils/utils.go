@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/mail"
 	"net/url"
+	"regexp"
+	"strings"
 )
 
 type Predicate[T any] func(T) bool
@@ -29,6 +31,28 @@ func ValidUrl(str string) error {
 	return nil
 }
 
+// a ROR ID is a 0, 6 characters and a 2 digit checksum, see: https://ror.readme.io/docs/identifier
+var rorId = regexp.MustCompile(`^0[a-hj-km-np-tv-z0-9]{6}[0-9]{2}$`)
+
+// returns the https://ror.org URL of a ROR ID given either bare, e.g., "05dxps055", or
+// as a URL. Identifiers which are not ROR IDs are returned unchanged.
+func NormalizeRorId(id string) (string, error) {
+	trimmed := strings.TrimSpace(id)
+	for _, prefix := range []string{"https://ror.org/", "http://ror.org/", "ror.org/"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			trimmed = strings.TrimPrefix(trimmed, prefix)
+			if !rorId.MatchString(trimmed) {
+				return "", fmt.Errorf("invalid ROR ID: %s", id)
+			}
+			return "https://ror.org/" + trimmed, nil
+		}
+	}
+	if rorId.MatchString(trimmed) {
+		return "https://ror.org/" + trimmed, nil
+	}
+	return id, nil
+}
+
 func ValidEmailAddress(address string) error {
 	_, err := mail.ParseAddress(address)
 	if err != nil {
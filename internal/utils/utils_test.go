@@ -58,3 +58,23 @@ func TestFilter4(t *testing.T) {
 	result := Filter(input, predicate)
 	g.Expect(result).To(gomega.Equal([]any{"hello", "world", "!"}))
 }
+
+func TestNormalizeRorId(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	id, err := NormalizeRorId("021nxhr62")
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(id).Should(gomega.Equal("https://ror.org/021nxhr62"))
+
+	id, err = NormalizeRorId("https://ror.org/021nxhr62")
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(id).Should(gomega.Equal("https://ror.org/021nxhr62"))
+
+	// other identifiers are kept
+	id, err = NormalizeRorId("https://www.wikidata.org/wiki/Q304878")
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(id).Should(gomega.Equal("https://www.wikidata.org/wiki/Q304878"))
+
+	_, err = NormalizeRorId("https://ror.org/not-a-ror-id")
+	g.Expect(err).ToNot(gomega.BeNil())
+}
@@ -0,0 +1,209 @@
+// Package codemeta reads, builds, validates and writes CodeMeta (https://codemeta.github.io)
+// documents, the codemeta.json files of software projects.
+//
+//	doc, err := codemeta.NewBuilder().
+//		Name("My Project").
+//		Description("A project").
+//		CodeRepository("https://github.com/org/myproject").
+//		ProgrammingLanguage(codemeta.NewComputerLanguage("Go", "https://go.dev")).
+//		Author(codemeta.NewPerson("Jane", "Doe").WithEmail("jane@example.org")).
+//		Build()
+//	if err == nil {
+//		err = doc.Validate()
+//	}
+package codemeta
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cacoco/codemetagenerator/internal/cue"
+)
+
+// parses a codemeta.json document
+func Parse(data []byte) (*SoftwareSourceCode, error) {
+	var s SoftwareSourceCode
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse codemeta.json document: %s", err.Error())
+	}
+	return &s, nil
+}
+
+// reads a codemeta.json document
+func Read(r io.Reader) (*SoftwareSourceCode, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// returns the compact JSON of the document. Unlike json.Marshal, HTML characters, e.g.,
+// the "&" of URLs, are not escaped.
+func (s *SoftwareSourceCode) JSON() ([]byte, error) {
+	return marshal(s)
+}
+
+// validates the document against the schema of the CodeMeta version of its "@context"
+func (s *SoftwareSourceCode) Validate() error {
+	data, err := s.JSON()
+	if err != nil {
+		return err
+	}
+	return cue.Validate(data)
+}
+
+// Builder builds a SoftwareSourceCode document. Every property set with the builder is
+// written, also when its value is empty. The "@type" of the document, and of the persons,
+// organizations and languages set with the builder, defaults to their schema.org type.
+type Builder struct {
+	doc SoftwareSourceCode
+	err error
+}
+
+// returns a builder of a document with the default "@context"
+func NewBuilder() *Builder {
+	b := &Builder{doc: SoftwareSourceCode{Extra: map[string]any{}}}
+	return b.Context(DefaultContext).set("@type", SoftwareSourceCodeType)
+}
+
+func (b *Builder) set(name string, value any) *Builder {
+	if b.err == nil {
+		err := b.doc.Set(name, value)
+		if err != nil {
+			b.err = fmt.Errorf("unable to set %s: %s", name, err.Error())
+		}
+	}
+	return b
+}
+
+// sets the "@context", e.g., "https://doi.org/10.5063/schema/codemeta-2.0"
+func (b *Builder) Context(context string) *Builder {
+	return b.set("@context", context)
+}
+
+func (b *Builder) Identifier(identifier string) *Builder {
+	return b.set("identifier", identifier)
+}
+
+func (b *Builder) Name(name string) *Builder {
+	return b.set("name", name)
+}
+
+func (b *Builder) Description(description string) *Builder {
+	return b.set("description", description)
+}
+
+func (b *Builder) Version(version string) *Builder {
+	return b.set("version", version)
+}
+
+// sets the license URL, e.g., "https://spdx.org/licenses/Apache-2.0"
+func (b *Builder) License(license string) *Builder {
+	return b.set("license", license)
+}
+
+func (b *Builder) CodeRepository(url string) *Builder {
+	return b.set("codeRepository", url)
+}
+
+func (b *Builder) IssueTracker(url string) *Builder {
+	return b.set("issueTracker", url)
+}
+
+func (b *Builder) ContinuousIntegration(urls ...string) *Builder {
+	return b.set("continuousIntegration", urls)
+}
+
+func (b *Builder) Readme(url string) *Builder {
+	return b.set("readme", url)
+}
+
+// sets the development status, see: https://www.repostatus.org/
+func (b *Builder) DevelopmentStatus(status string) *Builder {
+	return b.set("developmentStatus", status)
+}
+
+func (b *Builder) DateModified(date string) *Builder {
+	return b.set("dateModified", date)
+}
+
+func (b *Builder) DatePublished(date string) *Builder {
+	return b.set("datePublished", date)
+}
+
+func (b *Builder) Keywords(keywords ...string) *Builder {
+	return b.set("keywords", keywords)
+}
+
+// sets the programming languages, a single language is written as a single value
+func (b *Builder) ProgrammingLanguage(languages ...ComputerLanguage) *Builder {
+	for i := range languages {
+		if languages[i].Type == "" {
+			languages[i].Type = ComputerLanguageType
+		}
+	}
+	if len(languages) == 1 {
+		return b.set("programmingLanguage", languages[0])
+	}
+	return b.set("programmingLanguage", languages)
+}
+
+// sets the runtime platforms, a single platform is written as a single value
+func (b *Builder) RuntimePlatform(platforms ...string) *Builder {
+	if len(platforms) == 1 {
+		return b.set("runtimePlatform", platforms[0])
+	}
+	return b.set("runtimePlatform", platforms)
+}
+
+func (b *Builder) Author(agents ...Agent) *Builder {
+	return b.set("author", withTypes(agents))
+}
+
+func (b *Builder) Contributor(agents ...Agent) *Builder {
+	return b.set("contributor", withTypes(agents))
+}
+
+// sets the maintainers, a single maintainer is written as a single value
+func (b *Builder) Maintainer(agents ...Agent) *Builder {
+	agents = withTypes(agents)
+	if len(agents) == 1 {
+		return b.set("maintainer", agents[0])
+	}
+	return b.set("maintainer", agents)
+}
+
+// defaults the "@type" of the persons and organizations
+func withTypes(agents []Agent) []Agent {
+	for _, agent := range agents {
+		switch a := agent.(type) {
+		case *Person:
+			if a.Type == "" {
+				a.Type = PersonType
+			}
+		case *Organization:
+			if a.Type == "" {
+				a.Type = OrganizationType
+			}
+		}
+	}
+	return agents
+}
+
+// sets any property, e.g., "funding", the value is decoded into the field of the property
+// when it fits
+func (b *Builder) Set(name string, value any) *Builder {
+	return b.set(name, value)
+}
+
+// returns the document, or the first error setting a property
+func (b *Builder) Build() (*SoftwareSourceCode, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	doc := b.doc
+	return &doc, nil
+}
@@ -0,0 +1,209 @@
+package codemeta
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func parseAny(t *testing.T, data []byte) any {
+	var v any
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return v
+}
+
+func TestRoundTrip(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for _, path := range []string{"../../codemeta.json", "../../testdata/CodeMeta.json", "../../testdata/codemetaR.json", "../../testdata/testmeta.json"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		doc, err := Parse(data)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		encoded, err := json.Marshal(doc)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		g.Ω(parseAny(t, encoded)).Should(gomega.Equal(parseAny(t, data)), path)
+	}
+}
+
+func TestRoundTripUnknownProperties(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	data := []byte(`{
+		"@context": "https://w3id.org/codemeta/3.0",
+		"@type": "SoftwareSourceCode",
+		"name": "",
+		"version": 2,
+		"x-custom": {"nested": [1, 2.50, null]},
+		"keywords": "single",
+		"programmingLanguage": ["Go", {"@type": "ComputerLanguage", "name": "CUE"}],
+		"maintainer": {"@type": "Person", "givenName": "Jane", "affiliation": {"@type": "Organization", "name": "Org"}},
+		"author": [{"@type": "Organization", "name": "Org", "address": "Street 1"}],
+		"codeRepository": "https://example.org/?a=1&b=2"
+	}`)
+	doc, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g.Ω(doc.Keywords).Should(gomega.Equal([]string{"single"}))
+	g.Ω(doc.Maintainer).Should(gomega.HaveLen(1))
+	person := doc.Maintainer[0].(*Person)
+	g.Ω(person.GivenName).Should(gomega.Equal("Jane"))
+	g.Ω(person.Extra).Should(gomega.HaveKey("affiliation"))
+	g.Ω(doc.Author[0].(*Organization).Extra["address"]).Should(gomega.Equal("Street 1"))
+	// the mixed list and the number do not fit their fields
+	g.Ω(doc.ProgrammingLanguage).Should(gomega.BeEmpty())
+	g.Ω(doc.Extra).Should(gomega.HaveKey("programmingLanguage"))
+	g.Ω(doc.Extra).Should(gomega.HaveKey("version"))
+
+	encoded, err := doc.JSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g.Ω(parseAny(t, encoded)).Should(gomega.Equal(parseAny(t, data)))
+	g.Ω(string(encoded)).Should(gomega.ContainSubstring(`2.50`))
+	g.Ω(string(encoded)).Should(gomega.ContainSubstring(`a=1&b=2`))
+}
+
+func TestRoundTripNullsTypesAndNumbers(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	data := []byte(`{"keywords":null,"runtimePlatform":null,"continuousIntegration":null,"author":[{"givenName":"Jane"}],"maintainer":{"@type":"Person","familyName":"Doe"},"programmingLanguage":{"name":"Go"},"x-count":12345678901234567890}`)
+	doc, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// null does not fit a field and is kept as is
+	g.Ω(doc.Keywords).Should(gomega.BeNil())
+	g.Ω(doc.Extra).Should(gomega.HaveKeyWithValue("keywords", gomega.BeNil()))
+
+	encoded, err := doc.JSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// neither "@type" of the document nor of the maintainer is added
+	g.Ω(parseAny(t, encoded)).Should(gomega.Equal(parseAny(t, data)))
+	g.Ω(string(encoded)).Should(gomega.ContainSubstring(`"x-count":12345678901234567890`))
+
+	m, err := doc.Map()
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(m["x-count"]).Should(gomega.Equal(json.Number("12345678901234567890")))
+	g.Ω(m).Should(gomega.HaveKeyWithValue("keywords", gomega.BeNil()))
+
+	doc, err = FromMap(m)
+	g.Expect(err).To(gomega.BeNil())
+	encoded, err = doc.JSON()
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(parseAny(t, encoded)).Should(gomega.Equal(parseAny(t, data)))
+	g.Ω(string(encoded)).Should(gomega.ContainSubstring(`"x-count":12345678901234567890`))
+}
+
+func TestBuilderTypes(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	doc, err := NewBuilder().
+		Author(&Person{GivenName: "Jane"}).
+		Maintainer(&Organization{Name: "Org"}).
+		ProgrammingLanguage(ComputerLanguage{Name: "Go"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	m, err := doc.Map()
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(m["@type"]).Should(gomega.Equal(SoftwareSourceCodeType))
+	g.Ω(m["author"]).Should(gomega.Equal([]any{map[string]any{"@type": PersonType, "givenName": "Jane"}}))
+	g.Ω(m["maintainer"]).Should(gomega.Equal(map[string]any{"@type": OrganizationType, "name": "Org"}))
+	g.Ω(m["programmingLanguage"]).Should(gomega.Equal(map[string]any{"@type": ComputerLanguageType, "name": "Go"}))
+}
+
+func TestSetAndRemove(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	doc, err := Parse([]byte(`{"@type": "SoftwareSourceCode", "name": "name"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err = doc.Set("author", map[string]any{"@type": "Person", "givenName": "Jane", "email": ""})
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(doc.Author[0].(*Person).GivenName).Should(gomega.Equal("Jane"))
+	err = doc.Set("funding", "a grant")
+	g.Expect(err).To(gomega.BeNil())
+	value, ok := doc.Get("funding")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Ω(value).Should(gomega.Equal("a grant"))
+
+	doc.Remove("name")
+	doc.Remove("funding")
+	_, ok = doc.Get("name")
+	g.Expect(ok).To(gomega.BeFalse())
+
+	m, err := doc.Map()
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(m).Should(gomega.Equal(map[string]any{
+		"@type":  "SoftwareSourceCode",
+		"author": map[string]any{"@type": "Person", "givenName": "Jane", "email": ""},
+	}))
+
+	doc, err = FromMap(m)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(doc.Author).Should(gomega.HaveLen(1))
+}
+
+func TestBuilder(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	doc, err := NewBuilder().
+		Identifier("myproject").
+		Name("My Project").
+		Description("").
+		CodeRepository("https://github.com/org/myproject").
+		DevelopmentStatus("active").
+		Keywords("go", "codemeta").
+		ProgrammingLanguage(NewComputerLanguage("Go", "https://go.dev")).
+		RuntimePlatform("go1.21").
+		Author(NewPerson("Jane", "Doe").WithEmail("jane@example.org").WithId("https://orcid.org/0000-0000-0000-0000"), NewOrganization("Org").WithURL("https://example.org")).
+		Maintainer(NewPerson("Jane", "Doe").With("affiliation", "Org")).
+		Set("funding", "a grant").
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g.Expect(doc.Validate()).To(gomega.BeNil())
+
+	m, err := doc.Map()
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(m["@context"]).Should(gomega.Equal(DefaultContext))
+	g.Ω(m["description"]).Should(gomega.Equal(""))
+	g.Ω(m["runtimePlatform"]).Should(gomega.Equal("go1.21"))
+	g.Ω(m["maintainer"]).Should(gomega.Equal(map[string]any{"@type": "Person", "givenName": "Jane", "familyName": "Doe", "affiliation": "Org"}))
+	g.Ω(m["author"]).Should(gomega.HaveLen(2))
+	g.Ω(m["funding"]).Should(gomega.Equal("a grant"))
+
+	_, err = NewBuilder().Set("x", func() {}).Build()
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestValidate(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	doc, err := Read(strings.NewReader(`{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", "codeRepository": "not a url"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g.Expect(doc.Validate()).ToNot(gomega.BeNil())
+	_, err = Parse([]byte(`[]`))
+	g.Expect(err).ToNot(gomega.BeNil())
+}
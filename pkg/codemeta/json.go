@@ -0,0 +1,209 @@
+package codemeta
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// state records how the properties of a decoded value were written, so that encoding it
+// again does not lose data: which properties were present, e.g., with an empty string,
+// and which list properties were given as a single value
+type state struct {
+	present map[string]bool
+	singles map[string]bool
+}
+
+func (s *state) setPresent(name string, present bool) {
+	if s.present == nil {
+		s.present = map[string]bool{}
+	}
+	if present {
+		s.present[name] = true
+	} else {
+		delete(s.present, name)
+	}
+}
+
+func (s *state) setSingle(name string, single bool) {
+	if s.singles == nil {
+		s.singles = map[string]bool{}
+	}
+	if single {
+		s.singles[name] = true
+	} else {
+		delete(s.singles, name)
+	}
+}
+
+// returns the JSON property name of a struct field, e.g., "@id" for `json:"@id"`
+func propertyName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return "", false
+	}
+	return name, true
+}
+
+// returns the field of the struct v points to with the JSON property name
+func fieldOf(v any, name string) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if n, ok := propertyName(rt.Field(i)); ok && n == name {
+			return rv.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func decodeInto(field reflect.Value, raw []byte) bool {
+	if string(bytes.TrimSpace(raw)) == "null" {
+		return false
+	}
+	ptr := reflect.New(field.Type())
+	if json.Unmarshal(raw, ptr.Interface()) != nil {
+		return false
+	}
+	field.Set(ptr.Elem())
+	return true
+}
+
+// decodes a property into its field of the struct v points to. A single value of a list
+// field is decoded as a list of one value. Returns false when the value does not fit the
+// field, e.g., a list of mixed types or null, which is then kept as an extra property.
+func decodeProperty(v any, st *state, name string, raw []byte) bool {
+	field, ok := fieldOf(v, name)
+	if !ok {
+		return false
+	}
+	trimmed := bytes.TrimSpace(raw)
+	if string(trimmed) == "null" {
+		return false
+	}
+	if decodeInto(field, raw) {
+		st.setPresent(name, true)
+		st.setSingle(name, false)
+		return true
+	}
+	if field.Kind() == reflect.Slice && len(trimmed) > 0 && trimmed[0] != '[' {
+		list := append(append([]byte("["), trimmed...), ']')
+		if decodeInto(field, list) {
+			st.setPresent(name, true)
+			st.setSingle(name, true)
+			return true
+		}
+	}
+	return false
+}
+
+// decodes the JSON object into the fields of the struct v points to and returns the
+// properties which are not fields or do not fit their field. Numbers of these properties
+// are kept as json.Number so that they are encoded exactly as they were read.
+func decodeFields(data []byte, v any, st *state) (map[string]any, error) {
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+	*st = state{}
+	extra := map[string]any{}
+	for name, value := range raw {
+		if decodeProperty(v, st, name, value) {
+			continue
+		}
+		decoded, err := decodeAny(value)
+		if err != nil {
+			return nil, err
+		}
+		extra[name] = decoded
+	}
+	return extra, nil
+}
+
+func decodeAny(raw []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+// encodes the fields of the struct v together with the extra properties. A field is
+// encoded when it is set or was present when decoded, a field with the same name takes
+// precedence over an extra property.
+func encodeFields(v any, extra map[string]any, st state) ([]byte, error) {
+	m := map[string]any{}
+	for name, value := range extra {
+		m[name] = value
+	}
+	rv := reflect.ValueOf(v)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, ok := propertyName(rt.Field(i))
+		if !ok {
+			continue
+		}
+		field := rv.Field(i)
+		empty := field.IsZero() || (field.Kind() == reflect.Slice && field.Len() == 0)
+		if empty && !st.present[name] {
+			continue
+		}
+		if field.Kind() == reflect.Slice && field.IsNil() {
+			m[name] = []any{}
+		} else if field.Kind() == reflect.Slice && field.Len() == 1 && st.singles[name] {
+			m[name] = field.Index(0).Interface()
+		} else {
+			m[name] = field.Interface()
+		}
+	}
+	return marshal(m)
+}
+
+// marshals the value without escaping HTML characters, e.g., the "&" of URLs
+func marshal(v any) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
+
+// removes the property from the fields and the extra properties
+func removeProperty(v any, extra map[string]any, st *state, name string) {
+	if field, ok := fieldOf(v, name); ok {
+		field.Set(reflect.Zero(field.Type()))
+	}
+	delete(extra, name)
+	st.setPresent(name, false)
+	st.setSingle(name, false)
+}
+
+// sets the property to the value, which is decoded into its field when it fits and kept as
+// an extra property otherwise
+func setProperty(v any, extra *map[string]any, st *state, name string, value any) error {
+	raw, err := marshal(value)
+	if err != nil {
+		return err
+	}
+	removeProperty(v, *extra, st, name)
+	if decodeProperty(v, st, name, raw) {
+		return nil
+	}
+	decoded, err := decodeAny(raw)
+	if err != nil {
+		return err
+	}
+	if *extra == nil {
+		*extra = map[string]any{}
+	}
+	(*extra)[name] = decoded
+	return nil
+}
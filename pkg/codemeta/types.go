@@ -0,0 +1,303 @@
+package codemeta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cacoco/codemetagenerator/internal/model"
+)
+
+const (
+	// the "@context" of new documents
+	DefaultContext = model.DefaultContext
+
+	PersonType             = model.PersonType
+	OrganizationType       = model.OrganizationType
+	ComputerLanguageType   = model.ComputerLanguageType
+	SoftwareSourceCodeType = model.SoftwareSourceCodeType
+)
+
+// Agent is a Person or an Organization, e.g., an author of the software
+type Agent interface {
+	agentType() string
+}
+
+// Agents is a list of persons and organizations. Decoding fails when an item is neither
+// a Person nor an Organization.
+type Agents []Agent
+
+func (a *Agents) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+	agents := Agents{}
+	for _, item := range items {
+		var typed struct {
+			Type string `json:"@type"`
+		}
+		err := json.Unmarshal(item, &typed)
+		if err != nil {
+			return err
+		}
+		var agent Agent
+		switch typed.Type {
+		case PersonType:
+			agent = &Person{}
+		case OrganizationType:
+			agent = &Organization{}
+		default:
+			return fmt.Errorf("expected a %s or an %s, got: %q", PersonType, OrganizationType, typed.Type)
+		}
+		err = json.Unmarshal(item, agent)
+		if err != nil {
+			return err
+		}
+		agents = append(agents, agent)
+	}
+	*a = agents
+	return nil
+}
+
+// Person is a schema.org Person, see: https://schema.org/Person
+type Person struct {
+	Type       string `json:"@type"`
+	Id         string `json:"@id"`
+	GivenName  string `json:"givenName"`
+	FamilyName string `json:"familyName"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	URL        string `json:"url"`
+	Identifier string `json:"identifier"`
+	// the properties which are not fields of Person, e.g., "affiliation"
+	Extra map[string]any `json:"-"`
+	state
+}
+
+// returns a Person with the given and family names
+func NewPerson(givenName string, familyName string) *Person {
+	return &Person{Type: PersonType, GivenName: givenName, FamilyName: familyName}
+}
+
+func (p *Person) WithEmail(email string) *Person {
+	p.Email = email
+	return p
+}
+
+// sets the "@id" of the person, e.g., an ORCID iD
+func (p *Person) WithId(id string) *Person {
+	p.Id = id
+	return p
+}
+
+// sets a property which is not a field of Person, e.g., "affiliation"
+func (p *Person) With(name string, value any) *Person {
+	setProperty(p, &p.Extra, &p.state, name, value)
+	return p
+}
+
+func (p *Person) agentType() string {
+	return PersonType
+}
+
+func (p Person) MarshalJSON() ([]byte, error) {
+	return encodeFields(p, p.Extra, p.state)
+}
+
+func (p *Person) UnmarshalJSON(data []byte) error {
+	extra, err := decodeFields(data, p, &p.state)
+	p.Extra = extra
+	return err
+}
+
+// Organization is a schema.org Organization, see: https://schema.org/Organization
+type Organization struct {
+	Type       string `json:"@type"`
+	Id         string `json:"@id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	URL        string `json:"url"`
+	Identifier string `json:"identifier"`
+	// the properties which are not fields of Organization, e.g., "address"
+	Extra map[string]any `json:"-"`
+	state
+}
+
+// returns an Organization with the name
+func NewOrganization(name string) *Organization {
+	return &Organization{Type: OrganizationType, Name: name}
+}
+
+func (o *Organization) WithURL(url string) *Organization {
+	o.URL = url
+	return o
+}
+
+// sets the "@id" of the organization, e.g., a ROR ID
+func (o *Organization) WithId(id string) *Organization {
+	o.Id = id
+	return o
+}
+
+// sets a property which is not a field of Organization, e.g., "address"
+func (o *Organization) With(name string, value any) *Organization {
+	setProperty(o, &o.Extra, &o.state, name, value)
+	return o
+}
+
+func (o *Organization) agentType() string {
+	return OrganizationType
+}
+
+func (o Organization) MarshalJSON() ([]byte, error) {
+	return encodeFields(o, o.Extra, o.state)
+}
+
+func (o *Organization) UnmarshalJSON(data []byte) error {
+	extra, err := decodeFields(data, o, &o.state)
+	o.Extra = extra
+	return err
+}
+
+// ComputerLanguage is a programming language, see: https://schema.org/ComputerLanguage
+type ComputerLanguage struct {
+	Type    string `json:"@type"`
+	Id      string `json:"@id"`
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Version string `json:"version"`
+	// the properties which are not fields of ComputerLanguage, e.g., "alternateName"
+	Extra map[string]any `json:"-"`
+	state
+}
+
+// returns a ComputerLanguage with the name and URL
+func NewComputerLanguage(name string, url string) ComputerLanguage {
+	return ComputerLanguage{Type: ComputerLanguageType, Name: name, URL: url}
+}
+
+func (l ComputerLanguage) MarshalJSON() ([]byte, error) {
+	return encodeFields(l, l.Extra, l.state)
+}
+
+func (l *ComputerLanguage) UnmarshalJSON(data []byte) error {
+	extra, err := decodeFields(data, l, &l.state)
+	if err != nil {
+		return err
+	}
+	if l.Type != ComputerLanguageType {
+		return fmt.Errorf("expected a %s, got: %q", ComputerLanguageType, l.Type)
+	}
+	l.Extra = extra
+	return nil
+}
+
+// SoftwareSourceCode is a CodeMeta document, see: https://codemeta.github.io/terms/
+//
+// Decoding and encoding a document is lossless: the properties which are not fields of
+// the type, and the properties whose value does not fit their field, e.g., a
+// programmingLanguage given as a plain string or null, are kept in Extra. A list property
+// given as a single value is encoded as a single value again, and a missing "@type" is not
+// added, see Builder.
+type SoftwareSourceCode struct {
+	Context               string             `json:"@context"`
+	Type                  string             `json:"@type"`
+	Id                    string             `json:"@id"`
+	Identifier            string             `json:"identifier"`
+	Name                  string             `json:"name"`
+	Description           string             `json:"description"`
+	Version               string             `json:"version"`
+	License               string             `json:"license"`
+	CodeRepository        string             `json:"codeRepository"`
+	IssueTracker          string             `json:"issueTracker"`
+	ContinuousIntegration []string           `json:"continuousIntegration"`
+	Readme                string             `json:"readme"`
+	URL                   string             `json:"url"`
+	DevelopmentStatus     string             `json:"developmentStatus"`
+	DateCreated           string             `json:"dateCreated"`
+	DateModified          string             `json:"dateModified"`
+	DatePublished         string             `json:"datePublished"`
+	ReleaseNotes          string             `json:"releaseNotes"`
+	Keywords              []string           `json:"keywords"`
+	ProgrammingLanguage   []ComputerLanguage `json:"programmingLanguage"`
+	RuntimePlatform       []string           `json:"runtimePlatform"`
+	Author                Agents             `json:"author"`
+	Contributor           Agents             `json:"contributor"`
+	Maintainer            Agents             `json:"maintainer"`
+	// the properties which are not fields of SoftwareSourceCode, or whose value does not
+	// fit their field
+	Extra map[string]any `json:"-"`
+	state
+}
+
+func (s SoftwareSourceCode) MarshalJSON() ([]byte, error) {
+	return encodeFields(s, s.Extra, s.state)
+}
+
+func (s *SoftwareSourceCode) UnmarshalJSON(data []byte) error {
+	extra, err := decodeFields(data, s, &s.state)
+	s.Extra = extra
+	return err
+}
+
+// returns the value of the property, either a field or an extra property
+func (s *SoftwareSourceCode) Get(name string) (any, bool) {
+	if field, ok := fieldOf(s, name); ok {
+		if !field.IsZero() || s.present[name] {
+			return field.Interface(), true
+		}
+		return nil, false
+	}
+	value, ok := s.Extra[name]
+	return value, ok
+}
+
+// returns the value of the property as it is written, e.g., a []any of map[string]any for
+// a list of persons, for editing properties regardless of their field. Numbers are
+// json.Number.
+func (s *SoftwareSourceCode) Value(name string) (any, bool) {
+	m, err := s.Map()
+	if err != nil {
+		return nil, false
+	}
+	value, ok := m[name]
+	return value, ok
+}
+
+// sets the property to the value. The value is decoded into the field of the property
+// when it fits and is kept in Extra otherwise, e.g., a map[string]any of a Person is
+// decoded into the Author field.
+func (s *SoftwareSourceCode) Set(name string, value any) error {
+	return setProperty(s, &s.Extra, &s.state, name, value)
+}
+
+// removes the property, either a field or an extra property
+func (s *SoftwareSourceCode) Remove(name string) {
+	removeProperty(s, s.Extra, &s.state, name)
+}
+
+// returns the document as a map of its properties, e.g., for editing with paths. Numbers
+// are json.Number so that they are kept exactly as they were read.
+func (s *SoftwareSourceCode) Map() (map[string]any, error) {
+	data, err := s.JSON()
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&m)
+	return m, err
+}
+
+// returns the document of a map of its properties. Numbers given as json.Number, e.g., of
+// Map, are kept exactly.
+func FromMap(m map[string]any) (*SoftwareSourceCode, error) {
+	data, err := marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
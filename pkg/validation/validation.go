@@ -0,0 +1,255 @@
+// Package validation validates CodeMeta (https://codemeta.github.io) documents against the
+// schema of their CodeMeta version and reports each problem as a Diagnostic with the JSON
+// Pointer path and the position of the offending value.
+//
+// The schema is compiled once and reused. It is safe to validate from multiple goroutines,
+// but the validations share one CUE context, which is not safe for concurrent use, and are
+// run one at a time behind a lock, so concurrent validations do not run in parallel.
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	cueerrors "cuelang.org/go/cue/errors"
+	"github.com/cacoco/codemetagenerator/internal/cue"
+)
+
+type Severity string
+
+const (
+	SeverityError Severity = "error"
+	// a warning does not make a document invalid
+	SeverityWarning Severity = "warning"
+)
+
+// the codes of the diagnostics
+const (
+	// the document is not valid JSON
+	CodeInvalidJSON = "invalid-json"
+	// the property is not a CodeMeta property
+	CodeNotAllowed = "not-allowed"
+	// the value does not match the format of the property, e.g., of a URL or an email
+	CodeInvalidValue = "invalid-value"
+	// the value has a different type than the property, e.g., a list instead of a string
+	CodeTypeMismatch = "type-mismatch"
+	// the value is none of the values of the property, e.g., an unsupported "@type"
+	CodeConflictingValue = "conflicting-value"
+	// the value matches none of the types the property accepts, e.g., neither a Person
+	// nor an Organization
+	CodeNoMatch = "no-match"
+	// a required property is missing
+	CodeMissing = "missing"
+	CodeInvalid = "invalid"
+)
+
+// the filename the positions of the document are reported for
+const documentFilename = "codemeta.json"
+
+// Position is a position in the document, the line and column are 1-based and the offset
+// is the 0-based byte offset
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+// Diagnostic is a problem found in a document
+type Diagnostic struct {
+	// the JSON Pointer (RFC 6901) of the value, e.g., "/author/0/email", the empty
+	// pointer refers to the whole document
+	Path     string    `json:"path"`
+	Message  string    `json:"message"`
+	Code     string    `json:"code"`
+	Severity Severity  `json:"severity"`
+	Position *Position `json:"position,omitempty"`
+}
+
+// returns the diagnostic as, e.g., "/codeRepository (4:21): invalid value ..."
+func (d Diagnostic) String() string {
+	path := d.Path
+	if path == "" {
+		path = "/"
+	}
+	if d.Position != nil {
+		path += fmt.Sprintf(" (%d:%d)", d.Position.Line, d.Position.Column)
+	}
+	return fmt.Sprintf("%s: %s", path, d.Message)
+}
+
+// reports whether none of the diagnostics is an error
+func Valid(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// validates the document read from the reader
+func ValidateReader(r io.Reader) ([]Diagnostic, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Validate(data)
+}
+
+// validates the document against the schema of the CodeMeta version of its "@context" and
+// returns its diagnostics, which are empty for a valid document. The error is only set
+// when the document could not be validated at all.
+func Validate(data []byte) ([]Diagnostic, error) {
+	return ValidateVersion(data, "")
+}
+
+// validates the document against the schema of the CodeMeta version, "2.0" or "3.0". An
+// empty version is detected from the "@context" of the document.
+func ValidateVersion(data []byte, version string) ([]Diagnostic, error) {
+	var v any
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return []Diagnostic{syntaxDiagnostic(data, err)}, nil
+	}
+	errs, err := cue.Check(data, documentFilename, version)
+	if err != nil {
+		return nil, err
+	}
+	return diagnostics(errs), nil
+}
+
+func syntaxDiagnostic(data []byte, err error) Diagnostic {
+	d := Diagnostic{Message: err.Error(), Code: CodeInvalidJSON, Severity: SeverityError}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Offset > 0 {
+		// the offset is after the offending character
+		d.Position = positionOf(data, int(syntaxErr.Offset)-1)
+	} else {
+		d.Position = positionOf(data, len(data))
+	}
+	return d
+}
+
+// returns the position of the byte offset
+func positionOf(data []byte, offset int) *Position {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(before, '\n')
+	return &Position{Line: line, Column: column, Offset: offset}
+}
+
+// returns the JSON Pointer of the path of a CUE error, quoted labels, e.g., "@type", are
+// unquoted
+func pointer(path []string) string {
+	var b strings.Builder
+	for _, selector := range path {
+		if unquoted, err := strconv.Unquote(selector); err == nil {
+			selector = unquoted
+		}
+		selector = strings.ReplaceAll(selector, "~", "~0")
+		selector = strings.ReplaceAll(selector, "/", "~1")
+		b.WriteString("/" + selector)
+	}
+	return b.String()
+}
+
+// returns the position of the error in the document, the last position is the most
+// specific one
+func position(e cueerrors.Error) *Position {
+	var found *Position
+	for _, p := range cueerrors.Positions(e) {
+		if p.Filename() == documentFilename {
+			found = &Position{Line: p.Line(), Column: p.Column(), Offset: p.Offset()}
+		}
+	}
+	return found
+}
+
+func code(format string) string {
+	switch {
+	case strings.Contains(format, "empty disjunction"):
+		return CodeNoMatch
+	case strings.Contains(format, "not allowed"):
+		return CodeNotAllowed
+	case strings.Contains(format, "mismatched types"):
+		return CodeTypeMismatch
+	case strings.HasPrefix(format, "conflicting values"):
+		return CodeConflictingValue
+	case strings.HasPrefix(format, "invalid value"):
+		return CodeInvalidValue
+	case strings.Contains(format, "incomplete") || strings.Contains(format, "required"):
+		return CodeMissing
+	}
+	return CodeInvalid
+}
+
+// converts the errors to diagnostics. A value matching none of the types of a property
+// results in an error for each type, these are reported as a single diagnostic.
+func diagnostics(errs []cueerrors.Error) []Diagnostic {
+	disjunctions := map[string]bool{}
+	for _, e := range errs {
+		if format, _ := e.Msg(); code(format) == CodeNoMatch {
+			disjunctions[pointer(e.Path())] = true
+		}
+	}
+	inDisjunction := func(path string) bool {
+		for p := range disjunctions {
+			if strings.HasPrefix(path, p+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	result := []Diagnostic{}
+	for _, e := range errs {
+		path := pointer(e.Path())
+		format, args := e.Msg()
+		c := code(format)
+		message := fmt.Sprintf(format, args...)
+		switch {
+		case c == CodeNoMatch:
+			message = "the value matches none of the types of the property"
+		case disjunctions[path]:
+			// the errors of each type of the property
+			continue
+		case inDisjunction(path) && strings.HasSuffix(path, "/@type") && c == CodeConflictingValue:
+			// the "@type" of the value is not the type of this alternative
+			continue
+		}
+		result = append(result, Diagnostic{Path: path, Message: message, Code: c, Severity: SeverityError, Position: position(e)})
+	}
+	// disjunction errors do not always have a position, use one of their alternatives
+	for i, d := range result {
+		if d.Position != nil {
+			continue
+		}
+		for _, e := range errs {
+			if pointer(e.Path()) == d.Path {
+				if p := position(e); p != nil {
+					result[i].Position = p
+				}
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return offset(result[i]) < offset(result[j])
+	})
+	return result
+}
+
+func offset(d Diagnostic) int {
+	if d.Position == nil {
+		return -1
+	}
+	return d.Position.Offset
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestValidateValid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	file, err := os.Open("../../codemeta.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer file.Close()
+	diagnostics, err := ValidateReader(file)
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(diagnostics).Should(gomega.BeEmpty())
+	g.Expect(Valid(diagnostics)).To(gomega.BeTrue())
+}
+
+func TestValidateDiagnostics(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	diagnostics, err := Validate([]byte(`{
+  "@context": "https://w3id.org/codemeta/3.0",
+  "@type": "SoftwareSourceCode",
+  "codeRepository": "not a url",
+  "maintainer": {"@type": "Person", "email": "bad"},
+  "unknown": 1
+}`))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(Valid(diagnostics)).To(gomega.BeFalse())
+	g.Ω(diagnostics).Should(gomega.HaveLen(4))
+
+	g.Ω(diagnostics[0]).Should(gomega.Equal(Diagnostic{
+		Path:     "/codeRepository",
+		Message:  diagnostics[0].Message,
+		Code:     CodeInvalidValue,
+		Severity: SeverityError,
+		Position: &Position{Line: 4, Column: 21, Offset: 102},
+	}))
+	g.Ω(diagnostics[0].Message).Should(gomega.HavePrefix(`invalid value "not a url"`))
+	g.Ω(diagnostics[1].Path).Should(gomega.Equal("/maintainer"))
+	g.Ω(diagnostics[1].Code).Should(gomega.Equal(CodeNoMatch))
+	g.Ω(diagnostics[1].Position).ShouldNot(gomega.BeNil())
+	g.Ω(diagnostics[2].Path).Should(gomega.Equal("/maintainer/email"))
+	g.Ω(diagnostics[2].Code).Should(gomega.Equal(CodeInvalidValue))
+	g.Ω(diagnostics[3].Path).Should(gomega.Equal("/unknown"))
+	g.Ω(diagnostics[3].Code).Should(gomega.Equal(CodeNotAllowed))
+	g.Ω(diagnostics[3].Position.Line).Should(gomega.Equal(6))
+	g.Ω(diagnostics[3].String()).Should(gomega.Equal("/unknown (6:3): field not allowed"))
+}
+
+func TestValidateTypes(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	diagnostics, err := Validate([]byte(`{"@context": "https://w3id.org/codemeta/3.0", "@type": "NOTVALID", "name": ["a"]}`))
+	g.Expect(err).To(gomega.BeNil())
+	codes := map[string]string{}
+	for _, d := range diagnostics {
+		codes[d.Path] = d.Code
+	}
+	g.Ω(codes).Should(gomega.HaveKey("/@type"))
+	g.Ω(codes).Should(gomega.HaveKeyWithValue("/name", CodeTypeMismatch))
+}
+
+func TestValidateInvalidJSON(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	diagnostics, err := ValidateReader(strings.NewReader("{\n  \"name\": \"a\",\n  oops\n}"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Ω(diagnostics).Should(gomega.HaveLen(1))
+	g.Ω(diagnostics[0].Code).Should(gomega.Equal(CodeInvalidJSON))
+	g.Ω(diagnostics[0].Path).Should(gomega.Equal(""))
+	g.Ω(*diagnostics[0].Position).Should(gomega.Equal(Position{Line: 3, Column: 3, Offset: 19}))
+
+	diagnostics, _ = Validate([]byte(`{"name": `))
+	g.Ω(diagnostics[0].Code).Should(gomega.Equal(CodeInvalidJSON))
+}
+
+func TestValidateConcurrently(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var wg sync.WaitGroup
+	results := make([]int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			diagnostics, _ := Validate([]byte(`{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode", "readme": "not a url"}`))
+			results[i] = len(diagnostics)
+		}(i)
+	}
+	wg.Wait()
+	g.Ω(results).Should(gomega.HaveEach(1))
+}